@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"go_taskmanagement/database"
+	"go_taskmanagement/models"
+)
+
+// Scopes an API token can be limited to
+const (
+	ScopeTasksRead  = "tasks:read"  // Read tasks, projects, templates and time entries
+	ScopeTasksWrite = "tasks:write" // Change them too; includes tasks:read
+)
+
+// APITokenPrefix starts every API token, so the middleware can tell them from JWTs
+const APITokenPrefix = "gtm_"
+
+// lastUsedInterval limits how often last_used_at is written for a busy token
+const lastUsedInterval = time.Minute
+
+// Scopes lists every scope an API token may have
+var Scopes = []string{ScopeTasksRead, ScopeTasksWrite}
+
+// APIIdentity is the user and scopes an API token authenticates
+type APIIdentity struct {
+	TokenID  uint
+	UserID   uint
+	Username string
+	Email    string
+	Role     string
+	Scopes   []string
+}
+
+// IsScope reports whether s is a known scope
+func IsScope(s string) bool {
+	return slices.Contains(Scopes, s)
+}
+
+// HasScope reports whether granted covers the required scope
+func HasScope(granted []string, required string) bool {
+	if slices.Contains(granted, required) {
+		return true
+	}
+	return required == ScopeTasksRead && slices.Contains(granted, ScopeTasksWrite)
+}
+
+// IsAPIToken reports whether a bearer token is an API token rather than a JWT
+func IsAPIToken(raw string) bool {
+	return strings.HasPrefix(raw, APITokenPrefix)
+}
+
+// HashAPIToken is how API tokens are stored and looked up
+func HashAPIToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuthenticateAPIToken looks up an API token and its owner and records that it was used.
+// It returns ErrTokenExpired for an expired token and ErrTokenInvalid for an unknown one.
+func AuthenticateAPIToken(raw string) (*APIIdentity, error) {
+	now := time.Now()
+	hash := HashAPIToken(raw)
+	if database.IsConnected && database.DB != nil {
+		var token models.APIToken
+		if err := database.DB.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+			return nil, ErrTokenInvalid
+		}
+		if token.ExpiresAt != nil && !now.Before(*token.ExpiresAt) {
+			return nil, ErrTokenExpired
+		}
+		var user models.User
+		if err := database.DB.First(&user, token.UserID).Error; err != nil {
+			return nil, ErrTokenInvalid
+		}
+		if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedInterval {
+			if err := database.DB.Model(&token).Update("last_used_at", now).Error; err != nil {
+				return nil, err
+			}
+		}
+		return newAPIIdentity(token, user), nil
+	}
+
+	// In-memory mode (fallback)
+	i := slices.IndexFunc(models.APITokens, func(t models.APIToken) bool { return t.TokenHash == hash })
+	if i < 0 {
+		return nil, ErrTokenInvalid
+	}
+	token := &models.APITokens[i]
+	if token.ExpiresAt != nil && !now.Before(*token.ExpiresAt) {
+		return nil, ErrTokenExpired
+	}
+	u := slices.IndexFunc(models.Users, func(u models.User) bool { return u.ID == token.UserID })
+	if u < 0 {
+		return nil, ErrTokenInvalid
+	}
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedInterval {
+		token.LastUsedAt = &now
+	}
+	return newAPIIdentity(*token, models.Users[u]), nil
+}
+
+func newAPIIdentity(token models.APIToken, user models.User) *APIIdentity {
+	return &APIIdentity{
+		TokenID:  token.ID,
+		UserID:   user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		Role:     user.Role,
+		Scopes:   token.Scopes,
+	}
+}
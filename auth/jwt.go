@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AccessTokenTTL is how long an access token is valid
+const AccessTokenTTL = 15 * time.Minute
+
+const (
+	defaultIssuer   = "go_taskmanagement"
+	defaultAudience = "go_taskmanagement"
+	defaultLeeway   = 30 * time.Second
+)
+
+var (
+	// ErrTokenExpired is returned for a well-formed token that is past its exp
+	ErrTokenExpired = errors.New("token expired")
+	// ErrTokenInvalid is returned for any other token that fails verification
+	ErrTokenInvalid = errors.New("token invalid")
+	// ErrMissingUser is returned for a valid token that names no user
+	ErrMissingUser = errors.New("token has no user_id")
+
+	errUnknownKey = errors.New("unknown signing key")
+)
+
+func init() {
+	// iat carries microseconds so "log out everywhere" can tell tokens issued right
+	// before it from those issued right after
+	jwt.TimePrecision = time.Microsecond
+}
+
+// Claims are the claims of an access token
+type Claims struct {
+	UserID      uint     `json:"user_id"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	Role        string   `json:"role,omitempty"`
+	Permissions []string `json:"permissions,omitempty"` // Resolved from the role when the token was issued
+	jwt.RegisteredClaims
+}
+
+// Config controls how access tokens are signed and verified
+type Config struct {
+	Algorithm string   // HS256, RS256 or EdDSA
+	Secret    []byte   // HS256 only
+	Keys      *Keyring // RS256 and EdDSA only
+	Issuer    string
+	Audience  string
+	Leeway    time.Duration // Clock skew tolerated on exp, iat and nbf
+}
+
+var (
+	configOnce    sync.Once
+	defaultConfig Config
+)
+
+// DefaultConfig reads the JWT_* environment variables once and returns the resulting
+// settings. With JWT_SIGNING_ALG set to RS256 or EdDSA the keyring is loaded from
+// JWT_KEYS_DIR (default "keys"); a keyring that can't be loaded stops the server.
+func DefaultConfig() Config {
+	configOnce.Do(func() {
+		defaultConfig = Config{
+			Algorithm: getEnv("JWT_SIGNING_ALG", AlgHS256),
+			Secret:    []byte(getEnv("JWT_SECRET", "gizliAnahtar")), // Fallback for development
+			Issuer:    getEnv("JWT_ISSUER", defaultIssuer),
+			Audience:  getEnv("JWT_AUDIENCE", defaultAudience),
+			Leeway:    defaultLeeway,
+		}
+		if leeway, err := time.ParseDuration(os.Getenv("JWT_LEEWAY")); err == nil && leeway >= 0 {
+			defaultConfig.Leeway = leeway
+		}
+		if defaultConfig.Algorithm != AlgHS256 {
+			// A replaced key must outlive every token it signed
+			grace := AccessTokenTTL + defaultConfig.Leeway
+			keys, err := LoadKeyring(getEnv("JWT_KEYS_DIR", "keys"), defaultConfig.Algorithm, grace)
+			if err != nil {
+				log.Fatalf("Failed to load JWT signing keys: %v", err)
+			}
+			defaultConfig.Keys = keys
+		}
+	})
+	return defaultConfig
+}
+
+// Configure replaces the settings returned by DefaultConfig. Call it before serving requests.
+func Configure(cfg Config) {
+	configOnce.Do(func() {})
+	defaultConfig = cfg
+}
+
+// StartKeyRotation rotates the signing key every JWT_KEY_ROTATION_INTERVAL (a Go duration).
+// It does nothing for HS256 or when the interval is not set.
+func StartKeyRotation() {
+	cfg := DefaultConfig()
+	if cfg.Keys == nil {
+		return
+	}
+	interval, err := time.ParseDuration(os.Getenv("JWT_KEY_ROTATION_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return
+	}
+	cfg.Keys.StartRotation(interval)
+}
+
+// NewAccessToken signs an access token that is valid for AccessTokenTTL. The caller sets
+// the user claims and the jti; issuer, audience and lifetime are filled in here.
+func NewAccessToken(claims Claims) (string, error) {
+	return sign(claims, DefaultConfig().Audience, AccessTokenTTL)
+}
+
+// sign signs claims for the audience with the active key; access tokens and MFA
+// challenges only differ in audience and lifetime
+func sign(claims Claims, audience string, ttl time.Duration) (string, error) {
+	cfg := DefaultConfig()
+	now := time.Now()
+	claims.Issuer = cfg.Issuer
+	claims.Audience = jwt.ClaimStrings{audience}
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+	if cfg.Keys == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(cfg.Secret)
+	}
+
+	key := cfg.Keys.Active()
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(cfg.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// Verify checks the signature, algorithm, issuer, audience and lifetime of an access token
+// and returns its claims. exp, iat, jti and user_id are all required; asymmetric tokens
+// must name a kid that is still in the keyring.
+func Verify(tokenString string) (*Claims, error) {
+	return verify(tokenString, DefaultConfig().Audience)
+}
+
+// verify checks a token signed by sign for the audience
+func verify(tokenString, audience string) (*Claims, error) {
+	cfg := DefaultConfig()
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{cfg.Algorithm}),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(cfg.Leeway),
+	)
+
+	var claims Claims
+	_, err := parser.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		if cfg.Keys == nil {
+			return cfg.Secret, nil
+		}
+		kid, _ := token.Header["kid"].(string)
+		key, ok := cfg.Keys.Lookup(kid)
+		if !ok {
+			return nil, errUnknownKey
+		}
+		return key.Private.Public(), nil
+	})
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return nil, ErrTokenExpired
+	case err != nil:
+		return nil, ErrTokenInvalid
+	case claims.IssuedAt == nil || claims.ID == "":
+		return nil, ErrTokenInvalid
+	case claims.UserID == 0:
+		return nil, ErrMissingUser
+	}
+	return &claims, nil
+}
+
+// PublicKeys returns the JWKS other services use to verify tokens; it is empty for HS256
+func PublicKeys() JWKS {
+	cfg := DefaultConfig()
+	if cfg.Keys == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return cfg.Keys.JWKS()
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
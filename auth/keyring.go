@@ -0,0 +1,351 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	rsaKeyBits = 2048
+	// reloadInterval limits how often an unknown kid makes the keyring re-read its directory
+	reloadInterval = time.Minute
+)
+
+// Key is one signing key of the keyring. Keys are PEM files named <kid>.pem; the file's
+// modification time is when the key was created.
+type Key struct {
+	ID        string
+	Private   crypto.Signer
+	CreatedAt time.Time
+}
+
+// Keyring holds the asymmetric signing keys. The newest key signs new tokens; older keys
+// only verify and are kept until every token they signed has expired.
+type Keyring struct {
+	mu         sync.RWMutex
+	dir        string
+	alg        string
+	grace      time.Duration // How long a replaced key stays valid
+	keys       []*Key        // Oldest first
+	lastReload time.Time
+}
+
+// LoadKeyring reads the keys in dir for the algorithm (RS256 or EdDSA) and creates the
+// first key if the directory has none. grace is how long a key stays valid after a newer
+// one replaced it; it must cover the token lifetime plus clock skew.
+func LoadKeyring(dir, alg string, grace time.Duration) (*Keyring, error) {
+	if alg != AlgRS256 && alg != AlgEdDSA {
+		return nil, fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	k := &Keyring{dir: dir, alg: alg, grace: grace}
+	if err := k.Reload(); err != nil {
+		return nil, err
+	}
+	if len(k.keys) == 0 {
+		if _, err := k.Rotate(); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// Reload re-reads the key directory, picking up keys rotated by other instances
+func (k *Keyring) Reload() error {
+	entries, err := os.ReadDir(k.dir)
+	if err != nil {
+		return err
+	}
+	var keys []*Key
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".pem" {
+			continue
+		}
+		path := filepath.Join(k.dir, e.Name())
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		signer, err := readPrivateKey(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if keyAlgorithm(signer) != k.alg {
+			return fmt.Errorf("%s: key does not match %s", path, k.alg)
+		}
+		keys = append(keys, &Key{
+			ID:        strings.TrimSuffix(e.Name(), ".pem"),
+			Private:   signer,
+			CreatedAt: info.ModTime(),
+		})
+	}
+	sortKeys(keys)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+	k.lastReload = time.Now()
+	return nil
+}
+
+// Active returns the key that signs new tokens
+func (k *Keyring) Active() *Key {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if len(k.keys) == 0 {
+		return nil
+	}
+	return k.keys[len(k.keys)-1]
+}
+
+// Lookup returns the verification key with the kid, if it is still valid.
+// An unknown kid triggers a rate-limited reload, since another instance may have rotated.
+func (k *Keyring) Lookup(kid string) (*Key, bool) {
+	if key, ok := k.lookup(kid); ok {
+		return key, true
+	}
+	k.mu.RLock()
+	stale := time.Since(k.lastReload) >= reloadInterval
+	k.mu.RUnlock()
+	if !stale {
+		return nil, false
+	}
+	if err := k.Reload(); err != nil {
+		log.Printf("Failed to reload signing keys: %v", err)
+		return nil, false
+	}
+	return k.lookup(kid)
+}
+
+func (k *Keyring) lookup(kid string) (*Key, bool) {
+	now := time.Now()
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for i, key := range k.keys {
+		if key.ID == kid {
+			return key, k.validAt(i, now)
+		}
+	}
+	return nil, false
+}
+
+// validAt reports whether the key at index i may still verify tokens. Callers hold k.mu.
+func (k *Keyring) validAt(i int, now time.Time) bool {
+	if i == len(k.keys)-1 {
+		return true
+	}
+	replacedAt := k.keys[i+1].CreatedAt
+	return now.Before(replacedAt.Add(k.grace))
+}
+
+// Verifying returns the keys that may still verify tokens, oldest first
+func (k *Keyring) Verifying() []*Key {
+	now := time.Now()
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	var keys []*Key
+	for i, key := range k.keys {
+		if k.validAt(i, now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Rotate creates a new key and makes it the active one. The previous key keeps verifying
+// tokens for the grace period.
+func (k *Keyring) Rotate() (*Key, error) {
+	signer, err := generateKey(k.alg)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		return nil, err
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	kid := now.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix)
+	path := filepath.Join(k.dir, kid+".pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		return nil, err
+	}
+	key := &Key{ID: kid, Private: signer, CreatedAt: now}
+	if info, err := os.Stat(path); err == nil {
+		key.CreatedAt = info.ModTime()
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = append(k.keys, key)
+	sortKeys(k.keys)
+	return key, nil
+}
+
+// Prune removes keys whose grace period is over, from memory and from disk
+func (k *Keyring) Prune() (int, error) {
+	now := time.Now()
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	kept := make([]*Key, 0, len(k.keys))
+	var pruned int
+	for i, key := range k.keys {
+		if k.validAt(i, now) {
+			kept = append(kept, key)
+			continue
+		}
+		if err := os.Remove(filepath.Join(k.dir, key.ID+".pem")); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return pruned, err
+		}
+		pruned++
+	}
+	k.keys = kept
+	return pruned, nil
+}
+
+// StartRotation rotates the active key once it is older than interval and prunes expired
+// keys. It does nothing when interval is 0.
+func (k *Keyring) StartRotation(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	tick := min(interval, time.Hour)
+
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := k.Reload(); err != nil {
+				log.Printf("Failed to reload signing keys: %v", err)
+				continue
+			}
+			if active := k.Active(); active == nil || time.Since(active.CreatedAt) >= interval {
+				key, err := k.Rotate()
+				if err != nil {
+					log.Printf("Failed to rotate signing key: %v", err)
+				} else {
+					log.Printf("Rotated signing key, new kid %s", key.ID)
+				}
+			}
+			if pruned, err := k.Prune(); err != nil {
+				log.Printf("Failed to prune signing keys: %v", err)
+			} else if pruned > 0 {
+				log.Printf("Pruned %d expired signing keys", pruned)
+			}
+		}
+	}()
+	log.Printf("Signing key rotation started (interval %s)", interval)
+}
+
+// JWK is a public key in JSON Web Key format
+type JWK struct {
+	Kty string `json:"kty" example:"RSA"`
+	Kid string `json:"kid" example:"20261019T120000Z-1a2b3c4d"`
+	Use string `json:"use" example:"sig"`
+	Alg string `json:"alg" example:"RS256"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // OKP curve
+	X   string `json:"x,omitempty"`   // OKP public key
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys that may still verify tokens
+func (k *Keyring) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, key := range k.Verifying() {
+		jwk := JWK{Kid: key.ID, Use: "sig", Alg: k.alg}
+		switch pub := key.Private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func sortKeys(keys []*Key) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+}
+
+func generateKey(alg string) (crypto.Signer, error) {
+	if alg == AlgEdDSA {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	}
+	return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+}
+
+// readPrivateKey parses a PKCS#8 or PKCS#1 PEM private key
+func readPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+	return signer, nil
+}
+
+func keyAlgorithm(signer crypto.Signer) string {
+	switch signer.(type) {
+	case *rsa.PrivateKey:
+		return AlgRS256
+	case ed25519.PrivateKey:
+		return AlgEdDSA
+	}
+	return ""
+}
@@ -0,0 +1,24 @@
+package auth
+
+import "time"
+
+// MFAChallengeTTL is how long the second login step may take
+const MFAChallengeTTL = 5 * time.Minute
+
+// mfaAudience keeps challenge tokens from being accepted as access tokens
+func mfaAudience() string {
+	return DefaultConfig().Audience + "/mfa"
+}
+
+// NewMFAChallenge signs the token that LoginHandler returns instead of an access token
+// when the user has 2FA enabled. The caller sets a jti so the challenge can be used once.
+func NewMFAChallenge(userID uint, jti string) (string, error) {
+	claims := Claims{UserID: userID}
+	claims.ID = jti
+	return sign(claims, mfaAudience(), MFAChallengeTTL)
+}
+
+// VerifyMFAChallenge checks a challenge token and returns its claims
+func VerifyMFAChallenge(token string) (*Claims, error) {
+	return verify(token, mfaAudience())
+}
@@ -0,0 +1,41 @@
+package auth
+
+import "slices"
+
+// Permissions granted through roles
+const (
+	PermTasksModerate = "tasks:moderate" // Remove public tasks
+	PermUsersRead     = "users:read"     // List users and their roles
+	PermUsersManage   = "users:manage"   // Change a user's role
+	PermRolesManage   = "roles:manage"   // Create and delete custom roles
+)
+
+// Built-in roles; any other role is a custom role stored in the database
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Permissions lists every permission a role may grant
+var Permissions = []string{PermTasksModerate, PermUsersRead, PermUsersManage, PermRolesManage}
+
+// BuiltinRoles maps the built-in roles to their permissions
+var BuiltinRoles = map[string][]string{
+	RoleUser:  {},
+	RoleAdmin: Permissions,
+}
+
+// IsPermission reports whether p is a known permission
+func IsPermission(p string) bool {
+	return slices.Contains(Permissions, p)
+}
+
+// HasPermissions reports whether granted contains every one of required
+func HasPermissions(granted []string, required ...string) bool {
+	for _, p := range required {
+		if !slices.Contains(granted, p) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+// Package auth holds the access token state shared by the handlers that issue
+// tokens and the middleware that accepts them.
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"go_taskmanagement/database"
+	"go_taskmanagement/models"
+)
+
+// RevocationStore records revoked access tokens. The database table is the source of
+// truth shared by all instances; revocations are also cached in memory until the tokens
+// they cover expire, so a revoked token is rejected without a query. Without a database
+// the cache is the whole store. The verifier accepts tokens for the configured leeway
+// past their expiry, so revocations are kept that much longer.
+type RevocationStore struct {
+	mu     sync.Mutex
+	tokens map[string]time.Time // jti -> token expiry plus leeway
+	users  map[uint]userCutoff
+}
+
+type userCutoff struct {
+	before    time.Time // Tokens issued before this are revoked
+	expiresAt time.Time // When the last of those tokens expires, plus leeway
+}
+
+// Revocations is the store used by the middleware and the logout handlers
+var Revocations = NewRevocationStore()
+
+// NewRevocationStore returns an empty store
+func NewRevocationStore() *RevocationStore {
+	return &RevocationStore{
+		tokens: make(map[string]time.Time),
+		users:  make(map[uint]userCutoff),
+	}
+}
+
+// RevokeToken revokes a single access token until it expires
+func (s *RevocationStore) RevokeToken(jti string, userID uint, expiresAt time.Time) error {
+	now := time.Now()
+	expiresAt = expiresAt.Add(DefaultConfig().Leeway)
+	if database.IsConnected && database.DB != nil {
+		row := models.RevokedToken{JTI: jti, UserID: userID, RevokedAt: now, ExpiresAt: expiresAt}
+		if err := database.DB.Create(&row).Error; err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+	s.tokens[jti] = expiresAt
+	return nil
+}
+
+// RevokeUser revokes every access token issued to the user until now
+func (s *RevocationStore) RevokeUser(userID uint) error {
+	now := time.Now()
+	cutoff := userCutoff{before: now, expiresAt: now.Add(AccessTokenTTL + DefaultConfig().Leeway)}
+	if database.IsConnected && database.DB != nil {
+		row := models.RevokedToken{UserID: userID, RevokedAt: cutoff.before, ExpiresAt: cutoff.expiresAt}
+		if err := database.DB.Create(&row).Error; err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+	s.users[userID] = cutoff
+	return nil
+}
+
+// IsRevoked reports whether the token with the given ID, owner and issue time was revoked
+func (s *RevocationStore) IsRevoked(jti string, userID uint, issuedAt time.Time) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	if exp, ok := s.tokens[jti]; ok && now.Before(exp) {
+		s.mu.Unlock()
+		return true, nil
+	}
+	if c, ok := s.users[userID]; ok && now.Before(c.expiresAt) && issuedAt.Before(c.before) {
+		s.mu.Unlock()
+		return true, nil
+	}
+	s.mu.Unlock()
+
+	if !database.IsConnected || database.DB == nil {
+		return false, nil
+	}
+
+	// Another instance may have revoked it
+	var rows []models.RevokedToken
+	err := database.DB.
+		Where("expires_at > ? AND (jti = ? OR (jti = '' AND user_id = ? AND revoked_at > ?))", now, jti, userID, issuedAt).
+		Find(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, row := range rows {
+		if row.JTI != "" {
+			s.tokens[row.JTI] = row.ExpiresAt
+		} else if c := s.users[userID]; row.RevokedAt.After(c.before) {
+			s.users[userID] = userCutoff{before: row.RevokedAt, expiresAt: row.ExpiresAt}
+		}
+	}
+	return true, nil
+}
+
+// sweep drops cache entries whose tokens are no longer accepted. Callers hold s.mu.
+func (s *RevocationStore) sweep(now time.Time) {
+	for jti, exp := range s.tokens {
+		if !now.Before(exp) {
+			delete(s.tokens, jti)
+		}
+	}
+	for userID, c := range s.users {
+		if !now.Before(c.expiresAt) {
+			delete(s.users, userID)
+		}
+	}
+}
@@ -77,7 +77,7 @@ func Migrate() {
 		return
 	}
 
-	err := DB.AutoMigrate(&models.User{}, &models.Task{})
+	err := DB.AutoMigrate(&models.User{}, &models.Project{}, &models.CustomField{}, &models.Task{}, &models.TaskDependency{}, &models.TaskActivity{}, &models.TimeEntry{}, &models.ChecklistItem{}, &models.TaskTemplate{}, &models.RefreshToken{}, &models.RevokedToken{}, &models.Role{}, &models.PasswordReset{}, &models.APIToken{}, &models.RecoveryCode{}, &models.LoginAttempt{}, &models.AuditLog{})
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
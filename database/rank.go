@@ -0,0 +1,107 @@
+package database
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"go_taskmanagement/models"
+	"go_taskmanagement/rank"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRankMaxLength         = 16
+	defaultRankRebalanceInterval = time.Hour
+)
+
+// rankColumn identifies a status column: a user's tasks with the same project and status
+type rankColumn struct {
+	UserID    uint
+	ProjectID *uint
+	Status    string
+}
+
+// RebalanceRanks rewrites the ranks of the status columns whose keys have grown
+// longer than maxLength or that still contain tasks without a rank. The order
+// within each column is kept and the task versions are left alone, since the
+// board position does not change. It returns the number of columns rewritten.
+func RebalanceRanks(maxLength int) (int, error) {
+	if !IsConnected {
+		return 0, nil
+	}
+
+	var columns []rankColumn
+	err := DB.Unscoped().Model(&models.Task{}).
+		Select("user_id, project_id, status").
+		Group("user_id, project_id, status").
+		Having("MAX(LENGTH(rank)) > ? OR MIN(rank) = ''", maxLength).
+		Scan(&columns).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, col := range columns {
+		err := DB.Transaction(func(tx *gorm.DB) error {
+			// Same lock as moves, so no task is placed while the column is rewritten
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('task_rank'), ?)", int32(col.UserID)).Error; err != nil {
+				return err
+			}
+			// Trashed tasks are included so they keep their place when restored
+			query := tx.Unscoped().Model(&models.Task{}).Where("user_id = ? AND status = ?", col.UserID, col.Status)
+			if col.ProjectID != nil {
+				query = query.Where("project_id = ?", *col.ProjectID)
+			} else {
+				query = query.Where("project_id IS NULL")
+			}
+			var ids []uint
+			if err := query.Order("rank, id").Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			for i, key := range rank.Spread(len(ids)) {
+				if err := tx.Unscoped().Model(&models.Task{}).Where("id = ?", ids[i]).UpdateColumn("rank", key).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(columns), nil
+}
+
+// StartRankRebalancer runs RebalanceRanks in the background every RANK_REBALANCE_INTERVAL
+// (a Go duration, default 1h) for keys longer than RANK_MAX_LENGTH (default 16).
+// It does nothing in in-memory mode.
+func StartRankRebalancer() {
+	if !IsConnected {
+		return
+	}
+
+	maxLength, err := strconv.Atoi(getEnv("RANK_MAX_LENGTH", strconv.Itoa(defaultRankMaxLength)))
+	if err != nil || maxLength < 1 {
+		maxLength = defaultRankMaxLength
+	}
+	interval, err := time.ParseDuration(getEnv("RANK_REBALANCE_INTERVAL", defaultRankRebalanceInterval.String()))
+	if err != nil || interval <= 0 {
+		interval = defaultRankRebalanceInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			columns, err := RebalanceRanks(maxLength)
+			if err != nil {
+				log.Printf("Failed to rebalance task ranks: %v", err)
+			} else if columns > 0 {
+				log.Printf("Rebalanced ranks of %d task columns", columns)
+			}
+			<-ticker.C
+		}
+	}()
+	log.Printf("Rank rebalancer started (max length %d, interval %s)", maxLength, interval)
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"log"
+	"time"
+
+	"go_taskmanagement/lockout"
+	"go_taskmanagement/models"
+)
+
+const defaultTokenPurgeInterval = time.Hour
+
+// PurgeExpiredTokens deletes token revocations, refresh tokens and password reset tokens
+// that expired before the cutoff, and failed login counts that no longer matter. The
+// expiry stored with a revocation already includes the verifier's leeway.
+func PurgeExpiredTokens(cutoff time.Time) (int64, error) {
+	if !IsConnected {
+		return 0, nil
+	}
+
+	revoked := DB.Where("expires_at < ?", cutoff).Delete(&models.RevokedToken{})
+	if revoked.Error != nil {
+		return 0, revoked.Error
+	}
+	refresh := DB.Where("expires_at < ?", cutoff).Delete(&models.RefreshToken{})
+	if refresh.Error != nil {
+		return 0, refresh.Error
+	}
+	resets := DB.Where("expires_at < ?", cutoff).Delete(&models.PasswordReset{})
+	if resets.Error != nil {
+		return 0, resets.Error
+	}
+	attempts := DB.Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", cutoff.Add(-lockout.AccountPolicy().Lockout), cutoff).
+		Delete(&models.LoginAttempt{})
+	return revoked.RowsAffected + refresh.RowsAffected + resets.RowsAffected + attempts.RowsAffected, attempts.Error
+}
+
+// StartTokenPurger runs PurgeExpiredTokens in the background every TOKEN_PURGE_INTERVAL
+// (a Go duration, default 1h). It does nothing in in-memory mode.
+func StartTokenPurger() {
+	if !IsConnected {
+		return
+	}
+
+	interval, err := time.ParseDuration(getEnv("TOKEN_PURGE_INTERVAL", defaultTokenPurgeInterval.String()))
+	if err != nil || interval <= 0 {
+		interval = defaultTokenPurgeInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			purged, err := PurgeExpiredTokens(time.Now())
+			if err != nil {
+				log.Printf("Failed to purge expired tokens: %v", err)
+			} else if purged > 0 {
+				log.Printf("Purged %d expired tokens", purged)
+			}
+			<-ticker.C
+		}
+	}()
+	log.Printf("Token purger started (interval %s)", interval)
+}
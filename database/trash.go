@@ -0,0 +1,122 @@
+package database
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTrashRetentionDays = 30
+	defaultTrashPurgeInterval = time.Hour
+	trashPurgeBatch           = 500 // Tasks removed per transaction
+)
+
+// TrashRetention returns how long soft-deleted tasks are kept before they are purged.
+// Configured with TRASH_RETENTION_DAYS; 0 keeps trashed tasks forever.
+func TrashRetention() time.Duration {
+	days, err := strconv.Atoi(getEnv("TRASH_RETENTION_DAYS", strconv.Itoa(defaultTrashRetentionDays)))
+	if err != nil || days < 0 {
+		days = defaultTrashRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// PurgeTasks permanently deletes the tasks with their dependencies and checklist items.
+// Activity and time entries are kept as history, so time reports for past periods
+// don't change; a timer still running on a purged task is stopped at the purge.
+// Run it inside a transaction.
+func PurgeTasks(tx *gorm.DB, ids ...uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := tx.Where("blocker_id IN ? OR blocked_id IN ?", ids, ids).Delete(&models.TaskDependency{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("task_id IN ?", ids).Delete(&models.ChecklistItem{}).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+	err := tx.Model(&models.TimeEntry{}).Where("task_id IN ? AND ended_at IS NULL", ids).Updates(map[string]interface{}{
+		"ended_at":         now,
+		"duration_seconds": gorm.Expr("EXTRACT(EPOCH FROM (? - started_at))::bigint", now),
+		"updated_at":       now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Task{}).Error
+}
+
+// PurgeTrashedTasks permanently deletes tasks that were soft-deleted before the cutoff,
+// one transaction per batch, and records a purged entry in each task's history
+func PurgeTrashedTasks(cutoff time.Time) (int64, error) {
+	if !IsConnected {
+		return 0, nil
+	}
+
+	var purged int64
+	for {
+		var ids []uint
+		err := DB.Transaction(func(tx *gorm.DB) error {
+			err := tx.Unscoped().Model(&models.Task{}).
+				Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+				Order("id").Limit(trashPurgeBatch).Pluck("id", &ids).Error
+			if err != nil {
+				return err
+			}
+			if err := PurgeTasks(tx, ids...); err != nil {
+				return err
+			}
+			// The purge is recorded like a manual one, with no actor
+			entries := make([]models.TaskActivity, 0, len(ids))
+			for _, id := range ids {
+				entries = append(entries, models.TaskActivity{TaskID: id, Action: models.ActivityPurged})
+			}
+			if len(entries) == 0 {
+				return nil
+			}
+			return tx.Create(&entries).Error
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged += int64(len(ids))
+		if len(ids) < trashPurgeBatch {
+			return purged, nil
+		}
+	}
+}
+
+// StartTrashPurger runs PurgeTrashedTasks in the background every TRASH_PURGE_INTERVAL
+// (a Go duration, default 1h). It does nothing in in-memory mode or when retention is 0.
+func StartTrashPurger() {
+	retention := TrashRetention()
+	if !IsConnected || retention == 0 {
+		return
+	}
+
+	interval, err := time.ParseDuration(getEnv("TRASH_PURGE_INTERVAL", defaultTrashPurgeInterval.String()))
+	if err != nil || interval <= 0 {
+		interval = defaultTrashPurgeInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			purged, err := PurgeTrashedTasks(time.Now().Add(-retention))
+			if err != nil {
+				log.Printf("Failed to purge trashed tasks: %v", err)
+			} else if purged > 0 {
+				log.Printf("Purged %d trashed tasks", purged)
+			}
+			<-ticker.C
+		}
+	}()
+	log.Printf("Trash purger started (retention %s, interval %s)", retention, interval)
+}
@@ -15,41 +15,52 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/login": {
-            "post": {
-                "description": "Email ve şifre ile giriş yapar",
-                "consumes": [
-                    "application/json"
-                ],
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Access token'ları doğrulamak için kullanılabilecek açık anahtarlar. Token başlığındaki kid ile eşleşen anahtar seçilir. Rotasyonla değiştirilen anahtarlar imzaladıkları token'ların süresi dolana kadar listede kalır. HS256 kullanılıyorsa liste boştur.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Kullanıcı girişi",
-                "operationId": "LoginHandler",
-                "parameters": [
-                    {
-                        "description": "Email ve şifre",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
+                "summary": "JSON Web Key Set",
+                "operationId": "JWKSHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
+                            "$ref": "#/definitions/auth.JWKS"
                         }
                     }
+                }
+            }
+        },
+        "/2fa": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "İki adımlı doğrulamanın açık olup olmadığını ve kalan kurtarma kodu sayısını döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
                 ],
+                "summary": "2FA durumu",
+                "operationId": "MFAStatusHandler",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.MFAStatusResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -60,25 +71,78 @@ const docTemplate = `{
                 }
             }
         },
-        "/logout": {
+        "/2fa/confirm": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Kullanıcıyı çıkış yaptırır",
+                "description": "Authenticator uygulamasındaki ilk kodu doğrular, iki adımlı doğrulamayı açar ve 10 tek kullanımlık kurtarma kodu döner. Kurtarma kodları yalnızca bu yanıtta gösterilir.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Çıkış",
-                "operationId": "LogoutHandler",
+                "summary": "2FA'yı onayla",
+                "operationId": "MFAConfirmHandler",
+                "parameters": [
+                    {
+                        "description": "Authenticator kodu",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MFACodeRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -89,9 +153,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
+        "/2fa/disable": {
             "post": {
-                "description": "Yeni kullanıcı oluşturur",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Şifre ve geçerli bir authenticator ya da kurtarma kodu ile iki adımlı doğrulamayı kapatır; gizli anahtar ve kurtarma kodları silinir",
                 "consumes": [
                     "application/json"
                 ],
@@ -101,24 +170,27 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Kullanıcı kaydı",
-                "operationId": "RegisterHandler",
+                "summary": "2FA'yı kapat",
+                "operationId": "MFADisableHandler",
                 "parameters": [
                     {
-                        "description": "Kullanıcı kayıt bilgileri",
-                        "name": "user",
+                        "description": "Şifre ve kod",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.RegisterRequest"
+                            "$ref": "#/definitions/handlers.MFADisableRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -129,45 +201,95 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/tasks": {
-            "get": {
+        "/2fa/enroll": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Sadece giriş yapan kullanıcının görevlerini döner",
+                "description": "Yeni bir TOTP gizli anahtarı oluşturur ve authenticator uygulamasına eklenecek otpauth URI'sini döner. 2FA, POST /2fa/confirm ile ilk kod doğrulanana kadar kapalı kalır; tekrar çağrılırsa önceki bekleyen anahtar geçersiz olur.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Auth"
                 ],
-                "summary": "Kullanıcı görevlerini listele",
-                "operationId": "TasksListHandler",
+                "summary": "2FA kaydını başlat",
+                "operationId": "MFAEnrollHandler",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/handlers.MFAEnrollResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/2fa/recovery-codes": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Yeni görev oluşturur",
+                "description": "Geçerli bir authenticator ya da kurtarma kodu ile eski kurtarma kodlarını iptal eder ve 10 yeni kod döner",
                 "consumes": [
                     "application/json"
                 ],
@@ -175,26 +297,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Auth"
                 ],
-                "summary": "Görev ekle",
-                "operationId": "TaskCreateHandler",
+                "summary": "Kurtarma kodlarını yenile",
+                "operationId": "MFARecoveryCodesHandler",
                 "parameters": [
                     {
-                        "description": "Görev",
-                        "name": "task",
+                        "description": "Authenticator ya da kurtarma kodu",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/handlers.MFACodeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/handlers.RecoveryCodesResponse"
                         }
                     },
                     "400": {
@@ -205,55 +327,109 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/tasks/public": {
+        "/admin/lockouts": {
             "get": {
-                "description": "Herkesin görebileceği görevleri döner",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Çok fazla başarısız giriş nedeniyle şu anda kilitli olan hesapları ve IP adreslerini, kilidin en erken biteni önce olacak şekilde döner. users:read izni gerekir.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Admin"
                 ],
-                "summary": "Public görevleri listele",
-                "operationId": "PublicTasksHandler",
+                "summary": "Giriş kilitlerini listele",
+                "operationId": "AdminLockoutsListHandler",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Task"
+                                "$ref": "#/definitions/handlers.LockoutResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/tasks/{id}": {
-            "get": {
+        "/admin/lockouts/{kind}/{value}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Belirli bir görevin detayını döner",
+                "description": "Hesabın (kind=account, value=email) ya da IP adresinin (kind=ip) başarısız giriş sayacını sıfırlar; kilit ve bekleme süresi hemen kalkar. Her kilit kaldırma denetim kaydına yazılır. users:manage izni gerekir.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Admin"
                 ],
-                "summary": "Görev detayını görüntüle",
-                "operationId": "TaskDetailHandler",
+                "summary": "Giriş kilidini kaldır",
+                "operationId": "AdminLockoutDeleteHandler",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Görev ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "account ya da ip",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email ya da IP adresi",
+                        "name": "value",
                         "in": "path",
                         "required": true
                     }
@@ -262,7 +438,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
@@ -275,25 +472,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/public-tasks/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Belirli bir görevi günceller",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Herkese açık görev listesinden bir görevi kaldırır. tasks:moderate izni gerekir.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tasks"
+                    "Admin"
                 ],
-                "summary": "Görev güncelle",
-                "operationId": "TaskUpdateHandler",
+                "summary": "Public görevi kaldır",
+                "operationId": "AdminPublicTaskDeleteHandler",
                 "parameters": [
                     {
                         "type": "integer",
@@ -301,22 +497,34 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Görev",
-                        "name": "task",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Task"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
@@ -329,33 +537,289 @@ const docTemplate = `{
                         }
                     }
                 }
+            }
+        },
+        "/admin/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yerleşik user ve admin rollerini ve özel rolleri izinleriyle döner. roles:manage izni gerekir.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Rolleri listele",
+                "operationId": "AdminRolesListHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.RoleResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
             },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Verilen izinlerle yeni bir rol oluşturur. İzinler: tasks:moderate, users:read, users:manage, roles:manage. roles:manage izni gerekir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Özel rol oluştur",
+                "operationId": "AdminRoleCreateHandler",
+                "parameters": [
+                    {
+                        "description": "Rol adı ve izinleri",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RoleCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/roles/{name}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Belirli bir görevi siler",
+                "description": "Hiçbir kullanıcıya atanmamış özel rolü siler. Yerleşik roller silinemez. roles:manage izni gerekir.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Tasks"
+                    "Admin"
                 ],
-                "summary": "Görev sil",
-                "operationId": "TaskDeleteHandler",
+                "summary": "Özel rolü sil",
+                "operationId": "AdminRoleDeleteHandler",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rol adı",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tüm kullanıcıları rolleriyle döner. users:read izni gerekir.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Kullanıcıları listele",
+                "operationId": "AdminUsersListHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcıya yerleşik (user, admin) ya da özel bir rol atar. Kullanıcının mevcut access token'ları iptal edilir; yeni izinler token yenilendiğinde geçerli olur. Çağıran, hem atanan rolün hem de kullanıcının mevcut rolünün tüm izinlerine sahip olmalıdır; kendi rolünü değiştiremez. Son admin başka bir role alınamaz. users:manage izni gerekir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Kullanıcı rolünü değiştir",
+                "operationId": "AdminUserRoleHandler",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Görev ID",
+                        "description": "Kullanıcı ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Yeni rol",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserRoleRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
@@ -366,71 +830,4842 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcının API token'larını en yenisi önce olacak şekilde döner. Token değerleri gösterilmez; prefix ile ayırt edilir.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "API token'ları listele",
+                "operationId": "APITokensListHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIToken"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Script ve CI için kapsamı sınırlı bir kişisel erişim token'ı oluşturur. Kapsamlar: tasks:read, tasks:write (tasks:read'i de içerir). Token \"Authorization: Bearer gtm_...\" başlığıyla kullanılır ve yalnızca bu yanıtta gösterilir; veritabanında hash'i saklanır. Kullanıcı başına en fazla 20 token olabilir. API token'lar yalnızca görev, proje, şablon ve zaman kaydı uç noktalarında geçerlidir; token yönetimi için oturum gerekir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "API token oluştur",
+                "operationId": "APITokenCreateHandler",
+                "parameters": [
+                    {
+                        "description": "Token adı, kapsamları ve süresi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APITokenCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APITokenCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Token'ı siler; token ile yapılan sonraki istekler 401 alır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "API token'ı sil",
+                "operationId": "APITokenDeleteHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/burndown": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Her günün sonunda (UTC) kapsamdaki toplam tahmini, tamamlanan ve kalan işi görev geçmişinden hesaplar. Burndown için remaining, burnup için scope ve completed kullanılır. Yalnızca verilen birimde tahmini olan görevler sayılır. Varsayılan aralık son 14 gündür.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Burndown/burnup verisi",
+                "operationId": "BurndownHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Proje ID (verilmezse tüm görevler)",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Başlangıç günü (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bitiş günü, dahil (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hours",
+                            "points"
+                        ],
+                        "type": "string",
+                        "default": "points",
+                        "description": "Tahmin birimi",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BurndownResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Email ve şifre ile giriş yapar. 15 dakika geçerli bir access token ile POST /token/refresh üzerinden yenilenebilen tek kullanımlık bir refresh token döner. EMAIL_VERIFICATION=login ise email adresi doğrulanmamış kullanıcılar 403 alır. İki adımlı doğrulaması açık kullanıcılar token yerine mfa_required ve 5 dakika geçerli bir mfa_token alır; giriş POST /login/mfa ile tamamlanır. Başarısız girişler hesap ve IP başına sayılır: ilk ikisinden sonra her deneme öncesi bekleme süresi ikiye katlanır, LOGIN_MAX_FAILURES (varsayılan 5) hatadan sonra hesap LOGIN_LOCKOUT_DURATION (varsayılan 15 dakika) boyunca kilitlenir. Beklemesi gereken istekler Retry-After ile 429 alır.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Kullanıcı girişi",
+                "operationId": "LoginHandler",
+                "parameters": [
+                    {
+                        "description": "Email ve şifre",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "2FA açıksa MFAChallengeResponse",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/login/mfa": {
+            "post": {
+                "description": "POST /login'in döndüğü mfa_token ile authenticator kodunu ya da bir kurtarma kodunu doğrular ve access ve refresh token döner. mfa_token 5 dakika geçerlidir ve tek kullanımlıktır; kullanıcı başına 5 dakikada 5 deneme yapılabilir. Hatalı kodlar hatalı şifreler gibi hesap ve IP başına sayılır ve kilitlenmeye yol açar; hesabın sayacı ancak kod doğrulanınca sıfırlanır.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "2FA ile girişi tamamla",
+                "operationId": "LoginMFAHandler",
+                "parameters": [
+                    {
+                        "description": "MFA token'ı ve kod",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanılan access token'ı süresi dolana kadar iptal eder. Gövdede refresh_token gönderilirse aynı girişten türeyen refresh token'lar da iptal edilir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Çıkış",
+                "operationId": "LogoutHandler",
+                "parameters": [
+                    {
+                        "description": "İptal edilecek refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/logout/all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcıya şimdiye kadar verilmiş tüm access token'ları ve refresh token'ları iptal eder; kullanıcı her cihazda yeniden giriş yapmalıdır.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Tüm cihazlardan çıkış",
+                "operationId": "LogoutAllHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/password/forgot": {
+            "post": {
+                "description": "Email kayıtlıysa bir saat geçerli, tek kullanımlık bir şifre sıfırlama kodu gönderir; kod yeni şifreyle birlikte POST /password/reset isteğine token olarak verilir. Email'in kayıtlı olup olmadığı belli olmasın diye yanıt her zaman 202'dir. IP başına saatte 10 istek kabul edilir; aynı adrese saatte en fazla 3 e-posta gönderilir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Şifremi unuttum",
+                "operationId": "ForgotPasswordHandler",
+                "parameters": [
+                    {
+                        "description": "Hesabın e-posta adresi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/password/reset": {
+            "post": {
+                "description": "E-postadaki token ile yeni şifre belirler. Token tek kullanımlıktır. Başarılı sıfırlamadan sonra kullanıcının tüm oturumları kapatılır ve hesabın giriş kilidi kaldırılır.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Şifreyi sıfırla",
+                "operationId": "ResetPasswordHandler",
+                "parameters": [
+                    {
+                        "description": "Sıfırlama token'ı ve yeni şifre",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Giriş yapan kullanıcının projelerini iş akışlarıyla birlikte döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Projeleri listele",
+                "operationId": "ProjectsListHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Project"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kendi iş akışına sahip yeni bir proje oluşturur. İş akışı gönderilmezse varsayılan iş akışı kullanılır.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Proje oluştur",
+                "operationId": "ProjectCreateHandler",
+                "parameters": [
+                    {
+                        "description": "Proje",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProjectCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Projeyi iş akışıyla birlikte döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Proje detayını görüntüle",
+                "operationId": "ProjectDetailHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Proje ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/fields": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Projedeki görevlerin taşıyabileceği özel alan tanımlarını döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Özel alanları listele",
+                "operationId": "CustomFieldsListHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Proje ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CustomField"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Projeye tipli bir özel alan ekler. Anahtar küçük harf, rakam ve alt çizgiden oluşur ve proje içinde benzersizdir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Özel alan ekle",
+                "operationId": "CustomFieldCreateHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Proje ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alan tanımı",
+                        "name": "field",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CustomFieldCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CustomField"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/fields/{key}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Alan tanımını ve projedeki görevlerde bu alana ait değerleri siler",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Özel alanı sil",
+                "operationId": "CustomFieldDeleteHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Proje ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alan anahtarı",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/workflow": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Projenin iş akışını değiştirir. Kaldırılan bir durumda görev varsa status_map ile taşınacağı durum belirtilmelidir; görevler aynı transaction içinde taşınır. dry_run=true ile hiçbir şey değiştirmeden kaç görevin taşınacağı görülür.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Proje iş akışını değiştir",
+                "operationId": "ProjectWorkflowUpdateHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Proje ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sadece önizleme",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Yeni iş akışı",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProjectWorkflowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProjectWorkflowMigration"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Yeni kullanıcı oluşturur ve email adresine 24 saat geçerli, imzalı bir doğrulama bağlantısı gönderir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Kullanıcı kaydı",
+                "operationId": "RegisterHandler",
+                "parameters": [
+                    {
+                        "description": "Kullanıcı kayıt bilgileri",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sadece giriş yapan kullanıcının görevlerini döner. project_id ile projeye göre, cf.\u003calan\u003e=\u003cdeğer\u003e ile özel alanlara göre filtrelenir; sort=cf.\u003calan\u003e (azalan için -cf.\u003calan\u003e) ile özel alana göre sıralanır. Özel alan filtresi ve sıralaması project_id gerektirir. sort verilmezse görevler pano sırasıyla (durum, rank, id) döner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Kullanıcı görevlerini listele",
+                "operationId": "TasksListHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Proje ID",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sıralama, örn. -cf.story_points",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yeni görev oluşturur. EMAIL_VERIFICATION=tasks ise email adresi doğrulanmamış kullanıcılar 403 alır.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görev ekle",
+                "operationId": "TaskCreateHandler",
+                "parameters": [
+                    {
+                        "description": "Görev",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Oluşturma, güncelleme ve silme işlemlerini (ya da filtre ile eşleşen görevlere tek bir patch) tek transaction içinde uygular. atomic modda bir işlem başarısız olursa hepsi geri alınır, partial modda başarılı işlemler kalır. Boş bir filtre kullanıcının tüm görevlerine uyacağından yalnızca \"all\": true ile kabul edilir, aksi halde 422 döner. EMAIL_VERIFICATION=tasks ise email adresi doğrulanmamış kullanıcıların create içeren istekleri 403 alır.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Toplu görev işlemleri",
+                "operationId": "TaskBulkHandler",
+                "parameters": [
+                    {
+                        "description": "Toplu işlemler",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskBulkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskBulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskBulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskBulkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/public": {
+            "get": {
+                "description": "Herkesin görebileceği görevleri döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Public görevleri listele",
+                "operationId": "PublicTasksHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Task"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcının silinmiş görevlerini en son silinen önce olacak şekilde döner. purge_at, görevin kalıcı olarak silineceği zamandır.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Çöp kutusunu listele",
+                "operationId": "TrashListHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.TrashedTask"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Belirli bir görevin detayını döner. Açıklama CommonMark olarak yorumlanır; description_tasks açıklamadaki \"- [ ]\" / \"- [x]\" kutularını sayar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görev detayını görüntüle",
+                "operationId": "TaskDetailHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Önceki yanıttan alınan ETag",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "html verilirse açıklama güvenli HTML olarak description_html alanında döner",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Görevin güncel sürümü"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Görev değişmedi"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Belirli bir görevi gönderilen alanlarla tamamen değiştirir. Gönderilmeyen açıklama boş kabul edilir. Durum, görevin projesinin iş akışına uymalıdır. Açık engelleyen görevi olan görev force=true olmadan tamamlanamaz.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görev güncelle",
+                "operationId": "TaskUpdateHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Görevin beklenen ETag değeri",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Açık engelleyen görevler olsa da tamamla",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Görev",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskReplaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Görevin güncel sürümü"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Belirli bir görevi çöp kutusuna taşır. permanent=true ile görev (çöp kutusundaki dahil) kalıcı olarak silinir.",
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görev sil",
+                "operationId": "TaskDeleteHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Kalıcı olarak sil",
+                        "name": "permanent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Görevin beklenen ETag değeri",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gönderilmeyen alanlar değişmez, null gönderilen alanlar temizlenir (durum ve öncelik varsayılana döner). Başlık null olamaz.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görevi kısmen güncelle",
+                "operationId": "TaskPatchHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Görevin beklenen ETag değeri",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Açık engelleyen görevler olsa da tamamla",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Değişecek alanlar",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Görevin güncel sürümü"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/activity": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Görev üzerinde yapılan değişiklikleri en yeniden eskiye sayfalı olarak döner. Çöp kutusundaki görevler için de çalışır.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görev geçmişi",
+                "operationId": "TaskActivityHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Sayfa (1'den başlar)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Sayfa boyutu (en fazla 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskActivityPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Görevin kontrol listesi maddelerini sırasıyla döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Kontrol listesini görüntüle",
+                "operationId": "TaskChecklistHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ChecklistItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Maddeyi kontrol listesinin sonuna ekler",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Kontrol listesine madde ekle",
+                "operationId": "ChecklistItemCreateHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Madde",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ChecklistItemCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChecklistItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist/{item_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Maddeyi görevin kontrol listesinden kaldırır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Kontrol listesi maddesini sil",
+                "operationId": "ChecklistItemDeleteHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Madde ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Maddenin metnini ve/veya tamamlanma durumunu değiştirir. done kesin bir değer olarak gönderilir (true/false), bu yüzden aynı anda gelen işaretlemeler birbirini bozmaz.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Kontrol listesi maddesini güncelle",
+                "operationId": "ChecklistItemUpdateHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Madde ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Değişecek alanlar",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ChecklistItemUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChecklistItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist/{item_id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Maddeyi after_id maddesinin arkasına ya da before_id maddesinin önüne taşır; ikisi de verilmezse listenin sonuna koyar. Yalnızca taşınan madde yazılır. Güncel listeyi döner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Kontrol listesi maddesini taşı",
+                "operationId": "ChecklistItemMoveHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Madde ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hedef konum",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ChecklistItemMoveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ChecklistItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/dependencies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Görevi engelleyen ve görevin engellediği görevleri döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görev bağımlılıklarını listele",
+                "operationId": "TaskDependenciesHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskDependenciesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "blocker_id ile verilen görevin bu görevi engellediğini kaydeder. Döngü oluşturan bağımlılıklar reddedilir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Bağımlılık ekle",
+                "operationId": "TaskDependencyAddHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Engellenen görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Engelleyen görev",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskDependencyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskDependenciesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/dependencies/{blocker_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Verilen görevin bu görevi engellemesini kaldırır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Bağımlılığı kaldır",
+                "operationId": "TaskDependencyRemoveHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Engellenen görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Engelleyen görev ID",
+                        "name": "blocker_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskDependenciesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Görevi bir durum sütununa taşır ve sütunda after_id görevinin arkasına ya da before_id görevinin önüne yerleştirir; ikisi de verilmezse sütunun sonuna koyar. Durum ve sıra tek transaction içinde değişir, yalnızca taşınan görev yazılır. Durum değişikliği iş akışı kurallarına uyar.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görevi panoda taşı",
+                "operationId": "TaskMoveHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Görevin beklenen ETag değeri",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Açık engelleyen görevler olsa da tamamla",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Hedef sütun ve konum",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskMoveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Görevin güncel sürümü"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/reopen": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tamamlanmış bir görevi iş akışının izin verdiği bir duruma geri alır. Durum gönderilmezse başlangıç durumu kullanılır.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görevi yeniden aç",
+                "operationId": "TaskReopenHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Görevin beklenen ETag değeri",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Hedef durum",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TaskReopenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Görevin güncel sürümü"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Çöp kutusundaki görevi geri getirir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Görevi geri yükle",
+                "operationId": "TaskRestoreHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/time-entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Görevin tüm zaman kayıtlarını eskiden yeniye döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Görev zaman kayıtları",
+                "operationId": "TaskTimeEntriesHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TimeEntry"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Göreve başlangıç ile bitiş ya da süre vererek zaman kaydı ekler",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Zaman kaydı ekle",
+                "operationId": "TimeEntryCreateHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Zaman kaydı",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TimeEntryCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.TimeEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/timer/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Görev için zamanlayıcı başlatır. Kullanıcının aynı anda yalnızca bir çalışan zamanlayıcısı olabilir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Zamanlayıcı başlat",
+                "operationId": "TimerStartHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Görev ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Not",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TimerStartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.TimeEntry"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Giriş yapan kullanıcının görev şablonlarını döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Görev şablonlarını listele",
+                "operationId": "TemplatesListHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.TemplateResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Başlık, açıklama, öncelik, etiketler, kontrol listesi, göreli bitiş günü ve alt görevlerden oluşan bir şablon tanımlar. Metinlerde {{değişken}} yer tutucuları kullanılabilir; {{date}} başlangıç gününü verir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Görev şablonu oluştur",
+                "operationId": "TemplateCreateHandler",
+                "parameters": [
+                    {
+                        "description": "Şablon",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TemplateCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Şablonu ve içinde geçen değişken adlarını döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Görev şablonu detayı",
+                "operationId": "TemplateDetailHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Şablon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Şablonu siler; şablondan daha önce oluşturulan görevler kalır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Görev şablonunu sil",
+                "operationId": "TemplateDeleteHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Şablon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/instantiate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Şablondaki ana görevi, alt görevleri ve kontrol listelerini tek transaction içinde oluşturur. Değişkenler metinlere yerleştirilir, due_in_days başlangıç gününe eklenerek bitiş tarihi hesaplanır. Eksik değişken ya da geçersiz bir görev olursa hiçbir şey oluşturulmaz. EMAIL_VERIFICATION=tasks ise email adresi doğrulanmamış kullanıcılar 403 alır.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Şablondan görev oluştur",
+                "operationId": "TemplateInstantiateHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Şablon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Değişkenler ve başlangıç günü",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TemplateInstantiateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TemplateInstantiateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/time-entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcının verilen aralıkta başlayan zaman kayıtlarını görev ya da güne (UTC) göre toplar. Tarihler YYYY-MM-DD (bitiş günü dahil) ya da RFC 3339 olabilir; varsayılan son 7 gündür. Çalışan zamanlayıcı şu ana kadar sayılır.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Zaman raporu",
+                "operationId": "TimeReportHandler",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2025-08-01",
+                        "description": "Başlangıç",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-08-31",
+                        "description": "Bitiş",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "task",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "task",
+                        "description": "Gruplama",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TimeReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/time-entries/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Zaman kaydını sil",
+                "operationId": "TimeEntryDeleteHandler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Zaman kaydı ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/timer": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcının çalışan zamanlayıcısını döner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Çalışan zamanlayıcı",
+                "operationId": "TimerHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TimeEntry"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/timer/stop": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kullanıcının çalışan zamanlayıcısını durdurur ve süresini kaydeder",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Zamanlayıcıyı durdur",
+                "operationId": "TimerStopHandler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TimeEntry"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "Refresh token'ı tek kullanımlık olarak tüketir, yeni bir 15 dakikalık access token ve yeni bir refresh token döner. Daha önce kullanılmış bir refresh token tekrar gönderilirse aynı girişten türeyen tüm refresh token'lar iptal edilir ve yeniden giriş gerekir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Token yenile",
+                "operationId": "RefreshTokenHandler",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-email": {
+            "get": {
+                "description": "Kayıt e-postasındaki imzalı bağlantıyı doğrular ve email_verified_at alanını doldurur. Bağlantı 24 saat geçerlidir; email adresi değişmişse geçersiz olur. ADMIN_EMAILS içindeki bir adresi doğrulayan user rolündeki kullanıcı admin olur. Zaten doğrulanmış bir adres için de 200 döner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Email doğrula",
+                "operationId": "VerifyEmailHandler",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "E-postadaki doğrulama token'ı",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-email/resend": {
+            "post": {
+                "description": "Email kayıtlı ve henüz doğrulanmamışsa yeni bir doğrulama bağlantısı gönderir. Email'in kayıtlı olup olmadığı belli olmasın diye yanıt her zaman 202'dir. IP başına saatte 10 istek kabul edilir; aynı adrese saatte en fazla 3 e-posta gönderilir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Doğrulama e-postasını yeniden gönder",
+                "operationId": "ResendVerificationHandler",
+                "parameters": [
+                    {
+                        "description": "Hesabın e-posta adresi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string",
+                    "example": "RS256"
+                },
+                "crv": {
+                    "description": "OKP curve",
+                    "type": "string"
+                },
+                "e": {
+                    "description": "RSA exponent",
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string",
+                    "example": "20261019T120000Z-1a2b3c4d"
+                },
+                "kty": {
+                    "type": "string",
+                    "example": "RSA"
+                },
+                "n": {
+                    "description": "RSA modulus",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "description": "OKP public key",
+                    "type": "string"
+                }
+            }
+        },
+        "auth.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/auth.JWK"
+                    }
+                }
+            }
+        },
+        "handlers.APITokenCreateRequest": {
+            "type": "object",
+            "properties": {
+                "expires_in_days": {
+                    "description": "Verilmezse token süresizdir",
+                    "type": "integer",
+                    "example": 90
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ci"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tasks:read"
+                    ]
+                }
+            }
+        },
+        "handlers.APITokenCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "Nil means the token does not expire",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "description": "Updated at most once a minute",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ci"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "gtm_Q2xh"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tasks:read"
+                    ]
+                },
+                "token": {
+                    "type": "string",
+                    "example": "gtm_Q2xhdWRlIGlzIG5vdCBhIHRva2VuLCBqdXN0IGFuIGV4YW1wbGU"
+                }
+            }
+        },
+        "handlers.AuthUser": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "hakan@example.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "hakan"
+                }
+            }
+        },
+        "handlers.BurndownDay": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "description": "Tamamlanmış görevlerin toplam tahmini",
+                    "type": "number",
+                    "example": 15
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-08-25"
+                },
+                "ideal": {
+                    "description": "İlk günün kalan işinden sıfıra düz çizgi",
+                    "type": "number",
+                    "example": 26.7
+                },
+                "remaining": {
+                    "description": "scope - completed",
+                    "type": "number",
+                    "example": 25
+                },
+                "scope": {
+                    "description": "Kapsamdaki görevlerin toplam tahmini",
+                    "type": "number",
+                    "example": 40
+                }
+            }
+        },
+        "handlers.BurndownResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BurndownDay"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-08-18"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-08-31"
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "hours",
+                        "points"
+                    ]
+                }
+            }
+        },
+        "handlers.ChecklistItemCreateRequest": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Erişim yetkilerini ver"
+                }
+            }
+        },
+        "handlers.ChecklistItemMoveRequest": {
+            "type": "object",
+            "properties": {
+                "after_id": {
+                    "description": "Bu maddenin hemen arkasına yerleştir",
+                    "type": "integer",
+                    "example": 3
+                },
+                "before_id": {
+                    "description": "Bu maddenin hemen önüne yerleştir",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.ChecklistItemUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Erişim yetkilerini ver"
+                }
+            }
+        },
+        "handlers.CustomFieldCreateRequest": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "story_points"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Story points"
+                },
+                "options": {
+                    "description": "single_select ve multi_select için zorunlu",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "number",
+                        "date",
+                        "single_select",
+                        "multi_select",
+                        "text",
+                        "user"
+                    ],
+                    "example": "number"
+                }
+            }
+        },
+        "handlers.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "hakan@example.com"
+                }
+            }
+        },
+        "handlers.LockoutResponse": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "kind": {
+                    "description": "account ya da ip",
+                    "type": "string",
+                    "example": "account"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "ayse@example.com"
+                }
+            }
+        },
+        "handlers.LoginMFARequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Authenticator ya da kurtarma kodu",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "handlers.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "hakan@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "handlers.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "description": "Verilirse bu girişin refresh token'ları da iptal edilir",
+                    "type": "string",
+                    "example": "4q2Zb0cJ8wT1n0h3PqVx7kYl9mRfA2sDgHjKlZxCvBn"
+                }
+            }
+        },
+        "handlers.MFACodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "handlers.MFADisableRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Authenticator ya da kurtarma kodu",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                }
+            }
+        },
+        "handlers.MFAEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "type": "string",
+                    "example": "otpauth://totp/Task%20Management:hakan@example.com?algorithm=SHA1\u0026digits=6\u0026issuer=Task+Management\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "handlers.MFAStatusResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "enabled_at": {
+                    "type": "string"
+                },
+                "recovery_codes_remaining": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "handlers.ProjectCreateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Web sitesi"
+                },
+                "workflow": {
+                    "description": "Gönderilmezse varsayılan iş akışı kopyalanır",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/workflow.Workflow"
+                        }
+                    ]
+                }
+            }
+        },
+        "handlers.ProjectWorkflowMigration": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "migrated": {
+                    "description": "Eski durum başına taşınan (dry_run ise taşınacak) görev sayısı",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "project": {
+                    "$ref": "#/definitions/models.Project"
+                }
+            }
+        },
+        "handlers.ProjectWorkflowRequest": {
+            "type": "object",
+            "properties": {
+                "status_map": {
+                    "description": "Kaldırılan durumdaki görevlerin taşınacağı yeni durumlar (eski durum -\u003e yeni durum)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "review": "in_progress"
+                    }
+                },
+                "workflow": {
+                    "$ref": "#/definitions/workflow.Workflow"
+                }
+            }
+        },
+        "handlers.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k7fq-2mxa"
+                    ]
+                }
+            }
+        },
+        "handlers.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "4q2Zb0cJ8wT1n0h3PqVx7kYl9mRfA2sDgHjKlZxCvBn"
+                }
+            }
+        },
+        "handlers.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "hakan@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "1234"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "hakan"
+                }
+            }
+        },
+        "handlers.ResendVerificationRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "hakan@example.com"
+                }
+            }
+        },
+        "handlers.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "yeniSifre123"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "Zm9yZ290LXBhc3N3b3JkLXRva2Vu"
+                }
+            }
+        },
+        "handlers.RoleCreateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "moderator"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tasks:moderate"
+                    ]
+                }
+            }
+        },
+        "handlers.RoleResponse": {
+            "type": "object",
+            "properties": {
+                "built_in": {
+                    "description": "user ve admin rolleri değiştirilemez",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "moderator"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tasks:moderate"
+                    ]
+                }
+            }
+        },
+        "handlers.TaskActivityPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskActivity"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.TaskBulkFilter": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "description": "Kullanıcının tüm görevlerini güncelle",
+                    "type": "boolean"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "priority": {
+                    "type": "string",
+                    "example": "low"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "handlers.TaskBulkOperation": {
+            "type": "object",
+            "properties": {
+                "force": {
+                    "description": "Açık engelleyen görevler olsa da tamamla",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ],
+                    "example": "update"
+                },
+                "patch": {
+                    "type": "object"
+                },
+                "task": {
+                    "$ref": "#/definitions/handlers.TaskCreateRequest"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.TaskBulkRequest": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/handlers.TaskBulkFilter"
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "partial"
+                    ],
+                    "example": "atomic"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TaskBulkOperation"
+                    }
+                },
+                "patch": {
+                    "type": "object"
+                }
+            }
+        },
+        "handlers.TaskBulkResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TaskBulkResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.TaskBulkResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "task": {
+                    "$ref": "#/definitions/models.Task"
+                }
+            }
+        },
+        "handlers.TaskCreateRequest": {
+            "type": "object",
+            "properties": {
+                "custom_fields": {
+                    "description": "Alan anahtarı -\u003e değer",
+                    "type": "object"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Aylık raporu tamamla"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-12-31T23:59:59Z"
+                },
+                "estimate": {
+                    "type": "number",
+                    "example": 3
+                },
+                "estimate_unit": {
+                    "description": "Varsayılan points",
+                    "type": "string",
+                    "enum": [
+                        "hours",
+                        "points"
+                    ],
+                    "example": "points"
+                },
+                "parent_id": {
+                    "description": "Alt görev olarak eklenecek görev",
+                    "type": "integer",
+                    "example": 1
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ],
+                    "example": "medium"
+                },
+                "project_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "onboarding"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Rapor hazırla"
+                }
+            }
+        },
+        "handlers.TaskDependenciesResponse": {
+            "type": "object",
+            "properties": {
+                "blocked_by": {
+                    "description": "Bu görevi engelleyen görevler",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Task"
+                    }
+                },
+                "blocks": {
+                    "description": "Bu görevin engellediği görevler",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Task"
+                    }
+                }
+            }
+        },
+        "handlers.TaskDependencyRequest": {
+            "type": "object",
+            "properties": {
+                "blocker_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.TaskMoveRequest": {
+            "type": "object",
+            "properties": {
+                "after_id": {
+                    "description": "Bu görevin hemen arkasına yerleştir",
+                    "type": "integer",
+                    "example": 4
+                },
+                "before_id": {
+                    "description": "Bu görevin hemen önüne yerleştir",
+                    "type": "integer",
+                    "example": 5
+                },
+                "status": {
+                    "description": "Hedef sütun; verilmezse mevcut durum",
+                    "type": "string",
+                    "example": "in_progress"
+                }
+            }
+        },
+        "handlers.TaskPatchRequest": {
+            "type": "object",
+            "properties": {
+                "custom_fields": {
+                    "description": "null gönderilen anahtar silinir",
+                    "type": "object",
+                    "x-nullable": true
+                },
+                "description": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "Aylık raporu tamamla"
+                },
+                "due_date": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "2025-12-31T23:59:59Z"
+                },
+                "estimate": {
+                    "type": "number",
+                    "x-nullable": true,
+                    "example": 8
+                },
+                "estimate_unit": {
+                    "type": "string",
+                    "enum": [
+                        "hours",
+                        "points"
+                    ],
+                    "x-nullable": true,
+                    "example": "hours"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ],
+                    "x-nullable": true,
+                    "example": "low"
+                },
+                "status": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "completed"
+                },
+                "tags": {
+                    "description": "Listenin tamamı değişir",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-nullable": true,
+                    "example": [
+                        "onboarding"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Rapor hazırla"
+                }
+            }
+        },
+        "handlers.TaskReopenRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "in_progress"
+                }
+            }
+        },
+        "handlers.TaskReplaceRequest": {
+            "type": "object",
+            "properties": {
+                "custom_fields": {
+                    "description": "Gönderilmeyen özel alanlar temizlenir",
+                    "type": "object"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Aylık raporu tamamla"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-12-31T23:59:59Z"
+                },
+                "estimate": {
+                    "description": "Gönderilmezse tahmin temizlenir",
+                    "type": "number",
+                    "example": 5
+                },
+                "estimate_unit": {
+                    "type": "string",
+                    "enum": [
+                        "hours",
+                        "points"
+                    ],
+                    "example": "points"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ],
+                    "example": "high"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "in_progress"
+                },
+                "tags": {
+                    "description": "Gönderilmezse etiketler temizlenir",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "onboarding"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Rapor hazırla"
+                }
+            }
+        },
+        "handlers.TemplateCreateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Yeni çalışan"
+                },
+                "project_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "task": {
+                    "$ref": "#/definitions/models.TemplateTask"
+                }
+            }
+        },
+        "handlers.TemplateInstantiateRequest": {
+            "type": "object",
+            "properties": {
+                "start_date": {
+                    "description": "due_in_days bu güne eklenir; varsayılan bugün",
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "variables": {
+                    "description": "Değişken adı -\u003e değer",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.TemplateInstantiateResponse": {
+            "type": "object",
+            "properties": {
+                "tasks": {
+                    "description": "Önce ana görev, sonra alt görevler",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Task"
+                    }
+                }
+            }
+        },
+        "handlers.TemplateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Yeni çalışan"
+                },
+                "project_id": {
+                    "description": "Tasks are created in this project",
+                    "type": "integer"
+                },
+                "task": {
+                    "$ref": "#/definitions/models.TemplateTask"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "variables": {
+                    "description": "Şablonda geçen {{değişken}} adları",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "name"
+                    ]
+                }
+            }
+        },
+        "handlers.TimeEntryCreateRequest": {
+            "type": "object",
+            "properties": {
+                "duration_seconds": {
+                    "type": "integer",
+                    "example": 5400
+                },
+                "ended_at": {
+                    "type": "string",
+                    "example": "2025-08-25T10:30:00Z"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Analiz"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-08-25T09:00:00Z"
+                }
+            }
+        },
+        "handlers.TimeReport": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "string",
+                    "enum": [
+                        "task",
+                        "day"
+                    ]
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TimeReportGroup"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.TimeReportGroup": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "key": {
+                    "description": "Görev ID ya da YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2025-08-25"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "task_title": {
+                    "type": "string"
+                },
+                "total_seconds": {
+                    "type": "integer",
+                    "example": 5400
+                }
+            }
+        },
+        "handlers.TimerStartRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Müşteri toplantısı"
+                }
+            }
+        },
+        "handlers.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_in": {
+                    "description": "Access token ömrü (saniye)",
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "4q2Zb0cJ8wT1n0h3PqVx7kYl9mRfA2sDgHjKlZxCvBn"
+                },
+                "token": {
+                    "description": "access_token ile aynı; eski istemciler için",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "user": {
+                    "$ref": "#/definitions/handlers.AuthUser"
+                }
+            }
+        },
+        "handlers.TrashedTask": {
+            "type": "object",
+            "properties": {
+                "blocked": {
+                    "description": "Computed: an open task blocks this one",
+                    "type": "boolean"
+                },
+                "checklist": {
+                    "description": "Computed: done and total checklist items",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChecklistSummary"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "custom_fields": {
+                    "description": "Values of the project's custom fields",
+                    "type": "object"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "description": "CommonMark",
+                    "type": "string"
+                },
+                "description_html": {
+                    "description": "Rendered description, only with ?render=html",
+                    "type": "string"
+                },
+                "description_tasks": {
+                    "description": "Computed: task list checkboxes in the description",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChecklistSummary"
+                        }
+                    ]
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "estimate": {
+                    "description": "Hours or story points, see EstimateUnit",
+                    "type": "number",
+                    "example": 3
+                },
+                "estimate_unit": {
+                    "type": "string",
+                    "enum": [
+                        "hours",
+                        "points"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "description": "Set on subtasks",
+                    "type": "integer"
+                },
+                "priority": {
+                    "description": "low, medium, high",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
+                },
+                "project_id": {
+                    "description": "Tasks without a project follow the default workflow",
+                    "type": "integer"
+                },
+                "purge_at": {
+                    "type": "string"
+                },
+                "rank": {
+                    "description": "Position in the status column, see package rank",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "One of the statuses of the task's workflow",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "tracked_seconds": {
+                    "description": "Computed: total of the task's time entries",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Optimistic locking, exposed as ETag",
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.LoginRequest": {
+        },
+        "handlers.UserRoleRequest": {
             "type": "object",
             "properties": {
-                "email": {
+                "role": {
                     "type": "string",
-                    "example": "hakan@example.com"
-                },
-                "password": {
+                    "example": "admin"
+                }
+            }
+        },
+        "handlers.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string",
-                    "example": "1234"
+                    "example": "Doğrulama hatası"
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "handlers.RegisterRequest": {
+        "models.APIToken": {
             "type": "object",
             "properties": {
-                "email": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "Nil means the token does not expire",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "description": "Updated at most once a minute",
+                    "type": "string"
+                },
+                "name": {
                     "type": "string",
-                    "example": "hakan@example.com"
+                    "example": "ci"
                 },
-                "password": {
+                "prefix": {
                     "type": "string",
-                    "example": "1234"
+                    "example": "gtm_Q2xh"
                 },
-                "username": {
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tasks:read"
+                    ]
+                }
+            }
+        },
+        "models.ChecklistItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "done_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "Position in the checklist, see package rank",
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChecklistSummary": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.CustomField": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "Used in task.custom_fields and list queries",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "description": "Select types only",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
                     "type": "string",
-                    "example": "hakan"
+                    "enum": [
+                        "number",
+                        "date",
+                        "single_select",
+                        "multi_select",
+                        "text",
+                        "user"
+                    ]
+                }
+            }
+        },
+        "models.Project": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "workflow": {
+                    "$ref": "#/definitions/workflow.Workflow"
                 }
             }
         },
         "models.Task": {
             "type": "object",
             "properties": {
+                "blocked": {
+                    "description": "Computed: an open task blocks this one",
+                    "type": "boolean"
+                },
+                "checklist": {
+                    "description": "Computed: done and total checklist items",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChecklistSummary"
+                        }
+                    ]
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "custom_fields": {
+                    "description": "Values of the project's custom fields",
+                    "type": "object"
+                },
                 "description": {
+                    "description": "CommonMark",
+                    "type": "string"
+                },
+                "description_html": {
+                    "description": "Rendered description, only with ?render=html",
+                    "type": "string"
+                },
+                "description_tasks": {
+                    "description": "Computed: task list checkboxes in the description",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChecklistSummary"
+                        }
+                    ]
+                },
+                "due_date": {
                     "type": "string"
                 },
+                "estimate": {
+                    "description": "Hours or story points, see EstimateUnit",
+                    "type": "number",
+                    "example": 3
+                },
+                "estimate_unit": {
+                    "type": "string",
+                    "enum": [
+                        "hours",
+                        "points"
+                    ]
+                },
                 "id": {
                     "type": "integer"
                 },
+                "parent_id": {
+                    "description": "Set on subtasks",
+                    "type": "integer"
+                },
                 "priority": {
                     "description": "low, medium, high",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
+                },
+                "project_id": {
+                    "description": "Tasks without a project follow the default workflow",
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "Position in the status column, see package rank",
                     "type": "string"
                 },
                 "status": {
-                    "description": "pending, in_progress, completed",
+                    "description": "One of the statuses of the task's workflow",
                     "type": "string"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "title": {
                     "type": "string"
                 },
+                "tracked_seconds": {
+                    "description": "Computed: total of the task's time entries",
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
                 },
                 "user": {
                     "$ref": "#/definitions/models.User"
                 },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Optimistic locking, exposed as ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TaskActivity": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "created, updated, deleted, restored, purged",
+                    "type": "string"
+                },
+                "actor_id": {
+                    "description": "0 when the trash purger removed the task",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "field": {
+                    "description": "Set for updates only",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TemplateTask": {
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "description": "Checklist item texts",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_in_days": {
+                    "description": "Due date relative to the start date",
+                    "type": "integer",
+                    "example": 3
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
+                },
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateTask"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "{{name}} için işe alım"
+                }
+            }
+        },
+        "models.TimeEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "ended_at": {
+                    "description": "nil while the timer is running",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
                 "user_id": {
                     "type": "integer"
                 }
@@ -445,15 +5680,27 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "email_verified_at": {
+                    "description": "Set when the user opens the verification link",
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "role": {
+                    "description": "Built-in or custom role, see package auth",
+                    "type": "string"
+                },
                 "tasks": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/models.Task"
                     }
                 },
+                "totp_enabled_at": {
+                    "description": "Set once 2FA is confirmed with a first code",
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -461,6 +5708,45 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "workflow.Workflow": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "description": "Done lists the statuses that count as finished",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reopen": {
+                    "description": "Reopen maps a done status to the statuses reachable only through an explicit reopen",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "statuses": {
+                    "description": "Statuses in board order; the first one is the initial status",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transitions": {
+                    "description": "Transitions maps a status to the statuses reachable with a normal update",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
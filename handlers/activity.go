@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultActivityPageSize = 20
+	maxActivityPageSize     = 100
+)
+
+// TaskActivityPage görev geçmişi sayfası
+type TaskActivityPage struct {
+	Items []models.TaskActivity `json:"items"`
+	Page  int                   `json:"page" example:"1"`
+	Limit int                   `json:"limit" example:"20"`
+	Total int64                 `json:"total" example:"42"`
+}
+
+// recordTaskActivity appends a history entry; with a non-nil db it runs in the caller's transaction
+func recordTaskActivity(db *gorm.DB, taskID, actorID uint, action, field, oldValue, newValue string) error {
+	entry := models.TaskActivity{
+		TaskID:   taskID,
+		ActorID:  actorID,
+		Action:   action,
+		Field:    field,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+
+	if db != nil {
+		return db.Create(&entry).Error
+	}
+
+	// In-memory mode (fallback)
+	entry.ID = uint(len(models.TaskActivities) + 1)
+	entry.CreatedAt = time.Now()
+	models.TaskActivities = append(models.TaskActivities, entry)
+	return nil
+}
+
+// formatEstimate renders an estimate for the task history; no estimate is an empty string
+func formatEstimate(estimate *float64) string {
+	if estimate == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*estimate, 'f', -1, 64)
+}
+
+// formatDueDate renders a due date for the task history; no due date is an empty string
+func formatDueDate(due *time.Time) string {
+	if due == nil {
+		return ""
+	}
+	return due.UTC().Format(time.RFC3339)
+}
+
+// recordTaskChanges writes one "updated" entry per field that differs between before and after
+func recordTaskChanges(db *gorm.DB, actorID uint, before, after models.Task) error {
+	changes := []struct{ field, old, new string }{
+		{"title", before.Title, after.Title},
+		{"description", before.Description, after.Description},
+		{"status", before.Status, after.Status},
+		{"priority", before.Priority, after.Priority},
+		{"estimate", formatEstimate(before.Estimate), formatEstimate(after.Estimate)},
+		{"estimate_unit", before.EstimateUnit, after.EstimateUnit},
+		{"tags", strings.Join(before.Tags, ", "), strings.Join(after.Tags, ", ")},
+		{"due_date", formatDueDate(before.DueDate), formatDueDate(after.DueDate)},
+	}
+	changes = append(changes, customFieldChanges(before.CustomFields, after.CustomFields)...)
+	for _, ch := range changes {
+		if ch.old == ch.new {
+			continue
+		}
+		if err := recordTaskActivity(db, after.ID, actorID, models.ActivityUpdated, ch.field, ch.old, ch.new); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TaskActivityHandler görevin değişiklik geçmişini döner
+// @ID TaskActivityHandler
+// @Summary Görev geçmişi
+// @Description Görev üzerinde yapılan değişiklikleri en yeniden eskiye sayfalı olarak döner. Çöp kutusundaki görevler için de çalışır.
+// @Tags Tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param page query int false "Sayfa (1'den başlar)" default(1)
+// @Param limit query int false "Sayfa boyutu (en fazla 100)" default(20)
+// @Success 200 {object} TaskActivityPage
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tasks/{id}/activity [get]
+func TaskActivityHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	idStr := c.Params("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", defaultActivityPageSize)
+	if page < 1 || limit < 1 || limit > maxActivityPageSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz sayfalama parametreleri"})
+	}
+
+	db := taskDB()
+	if _, err := findUserTask(db, userID, uint(id)); err != nil {
+		if _, err := findTrashedTask(db, userID, uint(id)); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+		}
+	}
+
+	result := TaskActivityPage{Items: []models.TaskActivity{}, Page: page, Limit: limit}
+	offset := (page - 1) * limit
+
+	if db != nil {
+		query := db.Model(&models.TaskActivity{}).Where("task_id = ?", id)
+		if err := query.Count(&result.Total).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev geçmişi alınamadı"})
+		}
+		if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&result.Items).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev geçmişi alınamadı"})
+		}
+		return c.JSON(result)
+	}
+
+	// In-memory mode (fallback), newest first
+	var matched []models.TaskActivity
+	for i := len(models.TaskActivities) - 1; i >= 0; i-- {
+		if models.TaskActivities[i].TaskID == uint(id) {
+			matched = append(matched, models.TaskActivities[i])
+		}
+	}
+	result.Total = int64(len(matched))
+	if offset < len(matched) {
+		end := offset + limit
+		if end > len(matched) {
+			end = len(matched)
+		}
+		result.Items = matched[offset:end]
+	}
+	return c.JSON(result)
+}
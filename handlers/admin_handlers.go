@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/lockout"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+// UserRoleRequest kullanıcı rolü değiştirme isteği modeli
+type UserRoleRequest struct {
+	Role string `json:"role" example:"admin"`
+}
+
+// RoleCreateRequest özel rol oluşturma isteği modeli
+type RoleCreateRequest struct {
+	Name        string   `json:"name" example:"moderator"`
+	Permissions []string `json:"permissions" example:"tasks:moderate"`
+}
+
+// AdminUsersListHandler kullanıcıları rolleriyle listeler
+// @ID AdminUsersListHandler
+// @Summary Kullanıcıları listele
+// @Description Tüm kullanıcıları rolleriyle döner. users:read izni gerekir.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.User
+// @Failure 403 {object} map[string]string
+// @Router /admin/users [get]
+func AdminUsersListHandler(c *fiber.Ctx) error {
+	users, err := listUsers(taskDB())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kullanıcılar alınamadı"})
+	}
+	return c.JSON(users)
+}
+
+// AdminUserRoleHandler kullanıcının rolünü değiştirir
+// @ID AdminUserRoleHandler
+// @Summary Kullanıcı rolünü değiştir
+// @Description Kullanıcıya yerleşik (user, admin) ya da özel bir rol atar. Kullanıcının mevcut access token'ları iptal edilir; yeni izinler token yenilendiğinde geçerli olur. Çağıran, hem atanan rolün hem de kullanıcının mevcut rolünün tüm izinlerine sahip olmalıdır; kendi rolünü değiştiremez. Son admin başka bir role alınamaz. users:manage izni gerekir.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Kullanıcı ID"
+// @Param request body UserRoleRequest true "Yeni rol"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /admin/users/{id}/role [put]
+func AdminUserRoleHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz kullanıcı ID"})
+	}
+	var input UserRoleRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+
+	// An admin who demotes themselves can't undo it, and one who promotes themselves
+	// shouldn't be able to
+	if actorID, ok := c.Locals("user_id").(uint); ok && actorID == uint(id) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Kendi rolünüzü değiştiremezsiniz"})
+	}
+
+	db := taskDB()
+	if input.Role == "" {
+		return validationFailed(c, fieldErrors{"role": "Rol zorunlu"})
+	}
+	if _, err := rolePermissions(db, input.Role); err != nil {
+		return validationFailed(c, fieldErrors{"role": "Bilinmeyen rol"})
+	}
+	granted, _ := c.Locals("permissions").([]string)
+	if err := checkRoleAllowed(db, input.Role, granted); err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Sahip olmadığınız izinleri veren bir rol atayamazsınız"})
+	}
+
+	user, err := setUserRole(db, uint(id), input.Role, granted)
+	switch {
+	case errors.Is(err, errUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Kullanıcı bulunamadı"})
+	case errors.Is(err, errRoleNotAllowed):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Sizden daha yetkili bir kullanıcının rolünü değiştiremezsiniz"})
+	case errors.Is(err, errLastAdmin):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Son admin kullanıcının rolü değiştirilemez"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Rol değiştirilemedi"})
+	}
+
+	// Tokens carry the old permissions; the user picks up the new ones on refresh
+	if err := auth.Revocations.RevokeUser(user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Rol değiştirilemedi"})
+	}
+	return c.JSON(user)
+}
+
+// AdminRolesListHandler rolleri izinleriyle listeler
+// @ID AdminRolesListHandler
+// @Summary Rolleri listele
+// @Description Yerleşik user ve admin rollerini ve özel rolleri izinleriyle döner. roles:manage izni gerekir.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} RoleResponse
+// @Failure 403 {object} map[string]string
+// @Router /admin/roles [get]
+func AdminRolesListHandler(c *fiber.Ctx) error {
+	roles, err := listRoles(taskDB())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Roller alınamadı"})
+	}
+	return c.JSON(roles)
+}
+
+// AdminRoleCreateHandler özel rol oluşturur
+// @ID AdminRoleCreateHandler
+// @Summary Özel rol oluştur
+// @Description Verilen izinlerle yeni bir rol oluşturur. İzinler: tasks:moderate, users:read, users:manage, roles:manage. roles:manage izni gerekir.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RoleCreateRequest true "Rol adı ve izinleri"
+// @Success 201 {object} RoleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /admin/roles [post]
+func AdminRoleCreateHandler(c *fiber.Ctx) error {
+	var input RoleCreateRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+
+	errs := fieldErrors{}
+	if !roleNamePattern.MatchString(input.Name) {
+		errs["name"] = "Rol adı küçük harfle başlamalı, 2-50 karakter olmalı ve yalnızca küçük harf, rakam, _ ve - içermeli"
+	}
+	perms := []string{}
+	for _, p := range input.Permissions {
+		if !auth.IsPermission(p) {
+			errs["permissions"] = "Bilinmeyen izin: " + p
+			break
+		}
+		if !auth.HasPermissions(perms, p) {
+			perms = append(perms, p)
+		}
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	role := models.Role{Name: input.Name, Permissions: perms}
+	if err := createRole(taskDB(), &role); errors.Is(err, errRoleExists) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Bu isimde bir rol zaten var"})
+	} else if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Rol oluşturulamadı"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(RoleResponse{Name: role.Name, Permissions: role.Permissions})
+}
+
+// AdminRoleDeleteHandler özel rolü siler
+// @ID AdminRoleDeleteHandler
+// @Summary Özel rolü sil
+// @Description Hiçbir kullanıcıya atanmamış özel rolü siler. Yerleşik roller silinemez. roles:manage izni gerekir.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Rol adı"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /admin/roles/{name} [delete]
+func AdminRoleDeleteHandler(c *fiber.Ctx) error {
+	name := c.Params("name")
+	if _, ok := auth.BuiltinRoles[name]; ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Yerleşik roller silinemez"})
+	}
+	switch err := deleteRole(taskDB(), name); {
+	case errors.Is(err, errRoleNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Rol bulunamadı"})
+	case errors.Is(err, errRoleInUse):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Rol kullanıcılara atanmış, önce rollerini değiştirin"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Rol silinemedi"})
+	}
+	return c.JSON(fiber.Map{"message": "Rol silindi"})
+}
+
+// AdminPublicTaskDeleteHandler herkese açık bir görevi kaldırır
+// @ID AdminPublicTaskDeleteHandler
+// @Summary Public görevi kaldır
+// @Description Herkese açık görev listesinden bir görevi kaldırır. tasks:moderate izni gerekir.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /admin/public-tasks/{id} [delete]
+func AdminPublicTaskDeleteHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+	switch err := deletePublicTask(taskDB(), uint(id)); {
+	case errors.Is(err, errPublicNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Public görev bulunamadı"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev kaldırılamadı"})
+	}
+	return c.JSON(fiber.Map{"message": "Public görev kaldırıldı"})
+}
+
+// LockoutResponse başarısız giriş nedeniyle kilitlenen hesap ya da IP
+type LockoutResponse struct {
+	Kind        string    `json:"kind" example:"account"` // account ya da ip
+	Value       string    `json:"value" example:"ayse@example.com"`
+	Failures    int       `json:"failures" example:"5"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// AdminLockoutsListHandler kilitli hesapları ve IP'leri listeler
+// @ID AdminLockoutsListHandler
+// @Summary Giriş kilitlerini listele
+// @Description Çok fazla başarısız giriş nedeniyle şu anda kilitli olan hesapları ve IP adreslerini, kilidin en erken biteni önce olacak şekilde döner. users:read izni gerekir.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} LockoutResponse
+// @Failure 403 {object} map[string]string
+// @Router /admin/lockouts [get]
+func AdminLockoutsListHandler(c *fiber.Ctx) error {
+	locked, err := loginAttemptStore(taskDB()).Locked(time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kilitler alınamadı"})
+	}
+	out := make([]LockoutResponse, 0, len(locked))
+	for _, a := range locked {
+		kind, value := lockout.SplitKey(a.Key)
+		out = append(out, LockoutResponse{Kind: kind, Value: value, Failures: a.Failures, LockedUntil: *a.LockedUntil})
+	}
+	return c.JSON(out)
+}
+
+// AdminLockoutDeleteHandler hesabın ya da IP'nin giriş kilidini kaldırır
+// @ID AdminLockoutDeleteHandler
+// @Summary Giriş kilidini kaldır
+// @Description Hesabın (kind=account, value=email) ya da IP adresinin (kind=ip) başarısız giriş sayacını sıfırlar; kilit ve bekleme süresi hemen kalkar. Her kilit kaldırma denetim kaydına yazılır. users:manage izni gerekir.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param kind path string true "account ya da ip"
+// @Param value path string true "Email ya da IP adresi"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /admin/lockouts/{kind}/{value} [delete]
+func AdminLockoutDeleteHandler(c *fiber.Ctx) error {
+	kind := c.Params("kind")
+	if kind != lockout.KindAccount && kind != lockout.KindIP {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "kind account ya da ip olmalı"})
+	}
+	value, err := url.PathUnescape(c.Params("value"))
+	if err != nil || value == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz değer"})
+	}
+
+	db := taskDB()
+	store := loginAttemptStore(db)
+	key := lockout.Key(kind, value)
+	attempt, err := store.Get(key)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kilit kaldırılamadı"})
+	}
+	if attempt.Failures == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Başarısız giriş kaydı bulunamadı"})
+	}
+	if err := store.Reset(key); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kilit kaldırılamadı"})
+	}
+
+	entry := models.AuditLog{Action: models.AuditLoginUnlocked, Subject: key, IP: c.IP()}
+	if actorID, ok := c.Locals("user_id").(uint); ok {
+		entry.ActorID = &actorID
+	}
+	if kind == lockout.KindAccount {
+		if user, err := findUserByEmail(db, value); err == nil {
+			entry.UserID = &user.ID
+		}
+	}
+	if err := recordAudit(db, entry); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Denetim kaydı yazılamadı"})
+	}
+	return c.JSON(fiber.Map{"message": "Giriş kilidi kaldırıldı"})
+}
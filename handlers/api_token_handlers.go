@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxAPITokenDays = 365
+
+// APITokenCreateRequest API token oluşturma isteği modeli
+type APITokenCreateRequest struct {
+	Name          string   `json:"name" example:"ci"`
+	Scopes        []string `json:"scopes" example:"tasks:read"`
+	ExpiresInDays *int     `json:"expires_in_days,omitempty" example:"90"` // Verilmezse token süresizdir
+}
+
+// APITokenCreatedResponse yeni API token'ı; token değeri yalnızca bu yanıtta gösterilir
+type APITokenCreatedResponse struct {
+	models.APIToken
+	Token string `json:"token" example:"gtm_Q2xhdWRlIGlzIG5vdCBhIHRva2VuLCBqdXN0IGFuIGV4YW1wbGU"`
+}
+
+// APITokenCreateHandler kişisel API token'ı oluşturur
+// @ID APITokenCreateHandler
+// @Summary API token oluştur
+// @Description Script ve CI için kapsamı sınırlı bir kişisel erişim token'ı oluşturur. Kapsamlar: tasks:read, tasks:write (tasks:read'i de içerir). Token "Authorization: Bearer gtm_..." başlığıyla kullanılır ve yalnızca bu yanıtta gösterilir; veritabanında hash'i saklanır. Kullanıcı başına en fazla 20 token olabilir. API token'lar yalnızca görev, proje, şablon ve zaman kaydı uç noktalarında geçerlidir; token yönetimi için oturum gerekir.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body APITokenCreateRequest true "Token adı, kapsamları ve süresi"
+// @Success 201 {object} APITokenCreatedResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /api-tokens [post]
+func APITokenCreateHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	var input APITokenCreateRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+
+	errs := fieldErrors{}
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len([]rune(name)) > 100 {
+		errs["name"] = "Ad zorunlu ve en fazla 100 karakter olmalı"
+	}
+	scopes := []string{}
+	for _, s := range input.Scopes {
+		if !auth.IsScope(s) {
+			errs["scopes"] = "Bilinmeyen kapsam: " + s
+			break
+		}
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	if len(input.Scopes) == 0 {
+		errs["scopes"] = "En az bir kapsam zorunlu"
+	}
+	if d := input.ExpiresInDays; d != nil && (*d < 1 || *d > maxAPITokenDays) {
+		errs["expires_in_days"] = "Süre 1 ile " + strconv.Itoa(maxAPITokenDays) + " gün arasında olmalı"
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	token := models.APIToken{UserID: userID, Name: name, Scopes: scopes}
+	if input.ExpiresInDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, *input.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+	raw, err := createAPIToken(taskDB(), &token)
+	if errors.Is(err, errTooManyAPITokens) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "En fazla " + strconv.Itoa(maxAPITokens) + " API token oluşturulabilir"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "API token oluşturulamadı"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(APITokenCreatedResponse{APIToken: token, Token: raw})
+}
+
+// APITokensListHandler kullanıcının API token'larını listeler
+// @ID APITokensListHandler
+// @Summary API token'ları listele
+// @Description Kullanıcının API token'larını en yenisi önce olacak şekilde döner. Token değerleri gösterilmez; prefix ile ayırt edilir.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.APIToken
+// @Failure 401 {object} map[string]string
+// @Router /api-tokens [get]
+func APITokensListHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	tokens, err := listAPITokens(taskDB(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "API token'lar alınamadı"})
+	}
+	return c.JSON(tokens)
+}
+
+// APITokenDeleteHandler API token'ını iptal eder
+// @ID APITokenDeleteHandler
+// @Summary API token'ı sil
+// @Description Token'ı siler; token ile yapılan sonraki istekler 401 alır
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Token ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api-tokens/{id} [delete]
+func APITokenDeleteHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz token ID"})
+	}
+	switch err := deleteAPIToken(taskDB(), userID, uint(id)); {
+	case errors.Is(err, errAPITokenNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "API token bulunamadı"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "API token silinemedi"})
+	}
+	return c.JSON(fiber.Map{"message": "API token silindi"})
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"slices"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+// maxAPITokens caps how many API tokens a user can hold at once
+const maxAPITokens = 20
+
+var (
+	errAPITokenNotFound = errors.New("api token not found")
+	errTooManyAPITokens = errors.New("too many api tokens")
+)
+
+// createAPIToken stores a new API token for the user and returns its raw value, which
+// is never shown again
+func createAPIToken(db *gorm.DB, token *models.APIToken) (string, error) {
+	secret, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	raw := auth.APITokenPrefix + secret
+	token.TokenHash = auth.HashAPIToken(raw)
+	token.Prefix = raw[:len(auth.APITokenPrefix)+4]
+
+	if db != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var count int64
+			if err := tx.Model(&models.APIToken{}).Where("user_id = ?", token.UserID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count >= maxAPITokens {
+				return errTooManyAPITokens
+			}
+			return tx.Create(token).Error
+		})
+		return raw, err
+	}
+
+	// In-memory mode (fallback)
+	var count int
+	var maxID uint
+	for _, t := range models.APITokens {
+		if t.UserID == token.UserID {
+			count++
+		}
+		if t.ID > maxID {
+			maxID = t.ID
+		}
+	}
+	if count >= maxAPITokens {
+		return "", errTooManyAPITokens
+	}
+	token.ID = maxID + 1
+	models.APITokens = append(models.APITokens, *token)
+	return raw, nil
+}
+
+// listAPITokens returns the user's API tokens, newest first
+func listAPITokens(db *gorm.DB, userID uint) ([]models.APIToken, error) {
+	tokens := []models.APIToken{}
+	if db != nil {
+		err := db.Where("user_id = ?", userID).Order("id DESC").Find(&tokens).Error
+		return tokens, err
+	}
+
+	// In-memory mode (fallback)
+	for i := len(models.APITokens) - 1; i >= 0; i-- {
+		if models.APITokens[i].UserID == userID {
+			tokens = append(tokens, models.APITokens[i])
+		}
+	}
+	return tokens, nil
+}
+
+// deleteAPIToken revokes one of the user's API tokens
+func deleteAPIToken(db *gorm.DB, userID, id uint) error {
+	if db != nil {
+		res := db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.APIToken{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errAPITokenNotFound
+		}
+		return nil
+	}
+
+	// In-memory mode (fallback)
+	i := slices.IndexFunc(models.APITokens, func(t models.APIToken) bool { return t.ID == id && t.UserID == userID })
+	if i < 0 {
+		return errAPITokenNotFound
+	}
+	models.APITokens = slices.Delete(models.APITokens, i, i+1)
+	return nil
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"time"
+
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+// recordAudit appends a security event to the audit log
+func recordAudit(db *gorm.DB, entry models.AuditLog) error {
+	if db != nil {
+		return db.Create(&entry).Error
+	}
+
+	// In-memory mode (fallback)
+	entry.ID = uint(len(models.AuditLogs) + 1)
+	entry.CreatedAt = time.Now()
+	models.AuditLogs = append(models.AuditLogs, entry)
+	return nil
+}
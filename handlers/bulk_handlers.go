@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	bulkModeAtomic  = "atomic"
+	bulkModePartial = "partial"
+
+	maxBulkOperations = 500
+)
+
+var errBulkAborted = errors.New("bulk operation aborted")
+
+// TaskBulkOperation toplu istekteki tek bir işlem
+type TaskBulkOperation struct {
+	Op      string                     `json:"op" enums:"create,update,delete" example:"update"`
+	ID      uint                       `json:"id,omitempty" example:"1"`
+	Version uint                       `json:"version,omitempty" example:"3"`
+	Task    *TaskCreateRequest         `json:"task,omitempty"`
+	Patch   map[string]json.RawMessage `json:"patch,omitempty" swaggertype:"object"`
+	Force   bool                       `json:"force,omitempty"` // Açık engelleyen görevler olsa da tamamla
+}
+
+// TaskBulkFilter filtre ile toplu güncellemede eşleşecek görevler.
+// Boş bir filtre yalnızca all true ise kabul edilir.
+type TaskBulkFilter struct {
+	IDs      []uint `json:"ids,omitempty"`
+	Status   string `json:"status,omitempty" example:"pending"`
+	Priority string `json:"priority,omitempty" example:"low"`
+	All      bool   `json:"all,omitempty"` // Kullanıcının tüm görevlerini güncelle
+}
+
+// empty reports whether the filter matches every task of the user
+func (f TaskBulkFilter) empty() bool {
+	return len(f.IDs) == 0 && f.Status == "" && f.Priority == ""
+}
+
+// TaskBulkRequest toplu görev işlemi isteği modeli.
+// Ya operations ya da filter ile patch birlikte gönderilmelidir.
+type TaskBulkRequest struct {
+	Mode       string                     `json:"mode" enums:"atomic,partial" example:"atomic"`
+	Operations []TaskBulkOperation        `json:"operations,omitempty"`
+	Filter     *TaskBulkFilter            `json:"filter,omitempty"`
+	Patch      map[string]json.RawMessage `json:"patch,omitempty" swaggertype:"object"`
+}
+
+// TaskBulkResult tek bir işlemin sonucu
+type TaskBulkResult struct {
+	Index  int          `json:"index"`
+	Op     string       `json:"op"`
+	ID     uint         `json:"id,omitempty"`
+	Status int          `json:"status"`
+	Error  string       `json:"error,omitempty"`
+	Task   *models.Task `json:"task,omitempty"`
+}
+
+// TaskBulkResponse toplu görev işlemi yanıt modeli
+type TaskBulkResponse struct {
+	Mode      string           `json:"mode"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []TaskBulkResult `json:"results"`
+}
+
+// TaskBulkHandler birden fazla görev işlemini tek transaction içinde çalıştırır
+// @ID TaskBulkHandler
+// @Summary Toplu görev işlemleri
+// @Description Oluşturma, güncelleme ve silme işlemlerini (ya da filtre ile eşleşen görevlere tek bir patch) tek transaction içinde uygular. atomic modda bir işlem başarısız olursa hepsi geri alınır, partial modda başarılı işlemler kalır. Boş bir filtre kullanıcının tüm görevlerine uyacağından yalnızca "all": true ile kabul edilir, aksi halde 422 döner. EMAIL_VERIFICATION=tasks ise email adresi doğrulanmamış kullanıcıların create içeren istekleri 403 alır.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body TaskBulkRequest true "Toplu işlemler"
+// @Success 200 {object} TaskBulkResponse
+// @Success 207 {object} TaskBulkResponse
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} TaskBulkResponse
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tasks/bulk [post]
+func TaskBulkHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	var input TaskBulkRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+
+	if input.Mode == "" {
+		input.Mode = bulkModeAtomic
+	}
+	if input.Mode != bulkModeAtomic && input.Mode != bulkModePartial {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Mod atomic veya partial olmalı"})
+	}
+
+	ops := input.Operations
+	switch {
+	case len(ops) > 0 && (input.Filter != nil || input.Patch != nil):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "operations ile filter birlikte kullanılamaz"})
+	case len(ops) == 0 && input.Filter != nil && input.Patch != nil:
+		if input.Filter.empty() && !input.Filter.All {
+			return validationFailed(c, fieldErrors{"filter": "Filtre boş; tüm görevleri güncellemek için all: true gönderin"})
+		}
+		matched, err := filterUserTasks(taskDB(), userID, *input.Filter)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görevler alınamadı"})
+		}
+		for _, task := range matched {
+			ops = append(ops, TaskBulkOperation{Op: "update", ID: task.ID, Patch: input.Patch})
+		}
+	case len(ops) == 0:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "operations ya da filter ve patch zorunlu"})
+	}
+
+	if len(ops) > maxBulkOperations {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("En fazla %d işlem gönderilebilir", maxBulkOperations)})
+	}
+
+	if slices.ContainsFunc(ops, func(op TaskBulkOperation) bool { return op.Op == "create" }) {
+		if blocked, err := taskCreationBlocked(c, userID); blocked {
+			return err
+		}
+	}
+
+	resp, err := runBulkOperations(taskDB(), userID, ops, input.Mode == bulkModeAtomic)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Toplu işlem uygulanamadı"})
+	}
+
+	status := fiber.StatusOK
+	if resp.Failed > 0 {
+		status = fiber.StatusMultiStatus
+		if input.Mode == bulkModeAtomic {
+			status = fiber.StatusUnprocessableEntity
+		}
+	}
+	return c.Status(status).JSON(resp)
+}
+
+// runBulkOperations applies the operations in order inside one transaction.
+// In atomic mode the first failure rolls everything back; in partial mode each
+// item runs in its own savepoint so a failure only undoes that item. The error is
+// set when the transaction itself fails, and then nothing was applied.
+func runBulkOperations(db *gorm.DB, userID uint, ops []TaskBulkOperation, atomic bool) (TaskBulkResponse, error) {
+	resp := TaskBulkResponse{Mode: bulkModePartial, Results: make([]TaskBulkResult, 0, len(ops))}
+	if atomic {
+		resp.Mode = bulkModeAtomic
+	}
+
+	if db != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i, op := range ops {
+				savepoint := fmt.Sprintf("bulk_item_%d", i)
+				if !atomic {
+					if err := tx.SavePoint(savepoint).Error; err != nil {
+						return err
+					}
+				}
+				result := applyBulkOperation(tx, userID, i, op)
+				resp.Results = append(resp.Results, result)
+				if result.Status < 400 {
+					continue
+				}
+				if atomic {
+					return errBulkAborted
+				}
+				if err := tx.RollbackTo(savepoint).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errBulkAborted) {
+			return TaskBulkResponse{}, err
+		}
+	} else {
+		// In-memory mode (fallback): every item validates before it mutates,
+		// so a snapshot is enough to undo an aborted atomic batch
+		snapshot := append([]models.Task(nil), models.Tasks...)
+		activitySnapshot := append([]models.TaskActivity(nil), models.TaskActivities...)
+		for i, op := range ops {
+			result := applyBulkOperation(nil, userID, i, op)
+			resp.Results = append(resp.Results, result)
+			if result.Status >= 400 && atomic {
+				models.Tasks = snapshot
+				models.TaskActivities = activitySnapshot
+				break
+			}
+		}
+	}
+
+	aborted := false
+	for i := range resp.Results {
+		if resp.Results[i].Status >= 400 {
+			resp.Failed++
+			aborted = atomic
+		}
+	}
+	if aborted {
+		// Nothing was committed, so earlier successes are reported as rolled back
+		for i := range resp.Results {
+			if resp.Results[i].Status < 400 {
+				resp.Results[i].Status = fiber.StatusFailedDependency
+				resp.Results[i].Error = "İşlem geri alındı"
+				resp.Results[i].Task = nil
+				resp.Failed++
+			}
+		}
+	}
+	resp.Succeeded = len(resp.Results) - resp.Failed
+
+	var tasks []*models.Task
+	for i := range resp.Results {
+		if resp.Results[i].Task != nil {
+			tasks = append(tasks, resp.Results[i].Task)
+		}
+	}
+	setComputedFields(db, tasks...)
+	return resp, nil
+}
+
+// applyBulkOperation runs a single operation and reports its outcome as an HTTP status
+func applyBulkOperation(db *gorm.DB, userID uint, index int, op TaskBulkOperation) TaskBulkResult {
+	result := TaskBulkResult{Index: index, Op: op.Op, ID: op.ID}
+	fail := func(status int, msg string) TaskBulkResult {
+		result.Status = status
+		result.Error = msg
+		return result
+	}
+
+	switch op.Op {
+	case "create":
+		if op.Task == nil || op.Task.Title == "" {
+			return fail(fiber.StatusBadRequest, "Başlık zorunlu")
+		}
+		if err := validateTaskText(op.Task.Title, op.Task.Description); err != nil {
+			return fail(fiber.StatusBadRequest, err.Error())
+		}
+		if errs := validateTaskProject(db, userID, op.Task.ProjectID); len(errs) > 0 {
+			return fail(fiber.StatusUnprocessableEntity, errs.Error())
+		}
+		task := newTask(userID, *op.Task)
+		if errs := validateNewTask(db, &task); len(errs) > 0 {
+			return fail(fiber.StatusUnprocessableEntity, errs.Error())
+		}
+		if err := createTask(db, userID, &task); err != nil {
+			return fail(fiber.StatusInternalServerError, "Görev oluşturulamadı")
+		}
+		result.ID = task.ID
+		result.Status = fiber.StatusCreated
+		result.Task = &task
+		return result
+
+	case "update", "delete":
+		task, err := findUserTask(db, userID, op.ID)
+		if err != nil {
+			return fail(fiber.StatusNotFound, "Görev bulunamadı veya yetkiniz yok")
+		}
+		if op.Version != 0 && op.Version != task.Version {
+			return fail(fiber.StatusPreconditionFailed, "Görev başka bir istekle değiştirilmiş")
+		}
+
+		if op.Op == "delete" {
+			if err := deleteTask(db, userID, task); err != nil {
+				if errors.Is(err, errTaskVersionConflict) {
+					return fail(fiber.StatusPreconditionFailed, "Görev başka bir istekle değiştirilmiş")
+				}
+				return fail(fiber.StatusInternalServerError, "Görev silinemedi")
+			}
+			result.Status = fiber.StatusOK
+			return result
+		}
+
+		if op.Patch == nil {
+			return fail(fiber.StatusBadRequest, "patch zorunlu")
+		}
+		updates, err := mergePatchUpdates(task, op.Patch)
+		if err != nil {
+			return fail(fiber.StatusBadRequest, err.Error())
+		}
+		if err := validatePatchedTask(task, updates); err != nil {
+			return fail(fiber.StatusBadRequest, err.Error())
+		}
+		if errs := validateTaskUpdate(task, updates); len(errs) > 0 {
+			return fail(fiber.StatusUnprocessableEntity, errs.Error())
+		}
+		if errs := validateTaskBlockers(db, task, updates, op.Force); len(errs) > 0 {
+			return fail(fiber.StatusUnprocessableEntity, errs.Error())
+		}
+		if len(updates) > 0 {
+			if err := saveTaskUpdates(db, userID, task, updates); err != nil {
+				if errors.Is(err, errTaskVersionConflict) {
+					return fail(fiber.StatusPreconditionFailed, "Görev başka bir istekle değiştirilmiş")
+				}
+				return fail(fiber.StatusInternalServerError, "Görev güncellenemedi")
+			}
+		}
+		result.Status = fiber.StatusOK
+		updated := *task
+		result.Task = &updated
+		return result
+	}
+
+	return fail(fiber.StatusBadRequest, "İşlem create, update veya delete olmalı")
+}
+
+// filterUserTasks returns the user's tasks matching the bulk filter
+func filterUserTasks(db *gorm.DB, userID uint, filter TaskBulkFilter) ([]models.Task, error) {
+	if db != nil {
+		query := db.Where("user_id = ?", userID)
+		if len(filter.IDs) > 0 {
+			query = query.Where("id IN ?", filter.IDs)
+		}
+		if filter.Status != "" {
+			query = query.Where("status = ?", filter.Status)
+		}
+		if filter.Priority != "" {
+			query = query.Where("priority = ?", filter.Priority)
+		}
+		var tasks []models.Task
+		err := query.Order("id").Find(&tasks).Error
+		return tasks, err
+	}
+
+	// In-memory mode (fallback)
+	ids := make(map[uint]bool, len(filter.IDs))
+	for _, id := range filter.IDs {
+		ids[id] = true
+	}
+	var tasks []models.Task
+	for _, t := range models.Tasks {
+		if t.UserID != userID || t.DeletedAt.Valid {
+			continue
+		}
+		if len(ids) > 0 && !ids[t.ID] {
+			continue
+		}
+		if (filter.Status != "" && t.Status != filter.Status) || (filter.Priority != "" && t.Priority != filter.Priority) {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultBurndownDays = 14
+	maxBurndownDays     = 366
+)
+
+// BurndownDay bir günün sonundaki iş durumu
+type BurndownDay struct {
+	Date      string  `json:"date" example:"2025-08-25"`
+	Scope     float64 `json:"scope" example:"40"`     // Kapsamdaki görevlerin toplam tahmini
+	Completed float64 `json:"completed" example:"15"` // Tamamlanmış görevlerin toplam tahmini
+	Remaining float64 `json:"remaining" example:"25"` // scope - completed
+	Ideal     float64 `json:"ideal" example:"26.7"`   // İlk günün kalan işinden sıfıra düz çizgi
+}
+
+// BurndownResponse burndown/burnup grafiği verisi
+type BurndownResponse struct {
+	ProjectID *uint         `json:"project_id,omitempty"`
+	Unit      string        `json:"unit" enums:"hours,points"`
+	From      string        `json:"from" example:"2025-08-18"`
+	To        string        `json:"to" example:"2025-08-31"`
+	Days      []BurndownDay `json:"days"`
+}
+
+// taskState is the part of a task that burndown cares about, at some point in time
+type taskState struct {
+	status   string
+	estimate *float64
+	unit     string
+	deleted  bool
+}
+
+// BurndownHandler günlük kalan işi görev geçmişinden hesaplar
+// @ID BurndownHandler
+// @Summary Burndown/burnup verisi
+// @Description Her günün sonunda (UTC) kapsamdaki toplam tahmini, tamamlanan ve kalan işi görev geçmişinden hesaplar. Burndown için remaining, burnup için scope ve completed kullanılır. Yalnızca verilen birimde tahmini olan görevler sayılır. Varsayılan aralık son 14 gündür.
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Param project_id query int false "Proje ID (verilmezse tüm görevler)"
+// @Param from query string false "Başlangıç günü (YYYY-MM-DD)"
+// @Param to query string false "Bitiş günü, dahil (YYYY-MM-DD)"
+// @Param unit query string false "Tahmin birimi" Enums(hours, points) default(points)
+// @Success 200 {object} BurndownResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /burndown [get]
+func BurndownHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	unit := c.Query("unit", defaultEstimateUnit)
+	if !slices.Contains(models.EstimateUnits, unit) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "unit hours veya points olmalı"})
+	}
+
+	var projectID *uint
+	if raw := c.Query("project_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz proje ID"})
+		}
+		project, err := findUserProject(taskDB(), userID, uint(id))
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Proje bulunamadı veya yetkiniz yok"})
+		}
+		projectID = &project.ID
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	last, err := parseBurndownDay(c.Query("to"), today)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz bitiş tarihi"})
+	}
+	first, err := parseBurndownDay(c.Query("from"), last.AddDate(0, 0, 1-defaultBurndownDays))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz başlangıç tarihi"})
+	}
+	if last.Before(first) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Başlangıç bitişten sonra olamaz"})
+	}
+	days := int(last.Sub(first).Hours()/24) + 1
+	if days > maxBurndownDays {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Aralık en fazla %d gün olabilir", maxBurndownDays)})
+	}
+
+	tasks, activities, err := burndownHistory(taskDB(), userID, projectID, first)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev geçmişi alınamadı"})
+	}
+
+	resp := BurndownResponse{
+		ProjectID: projectID,
+		Unit:      unit,
+		From:      first.Format(customFieldDateLayout),
+		To:        last.Format(customFieldDateLayout),
+		Days:      make([]BurndownDay, days),
+	}
+	for i := range resp.Days {
+		resp.Days[i].Date = first.AddDate(0, 0, i).Format(customFieldDateLayout)
+	}
+
+	for i := range tasks {
+		task := &tasks[i]
+		wf := taskWorkflow(task)
+		state := taskState{status: task.Status, estimate: task.Estimate, unit: task.EstimateUnit, deleted: task.DeletedAt.Valid}
+		events := activities[task.ID]
+		next := 0
+
+		// Walk back from the task as it is now, undoing the changes made after each day
+		for d := days - 1; d >= 0; d-- {
+			dayEnd := first.AddDate(0, 0, d+1)
+			for ; next < len(events) && !events[next].CreatedAt.Before(dayEnd); next++ {
+				state.undo(events[next])
+			}
+			if !task.CreatedAt.Before(dayEnd) || state.deleted || state.estimate == nil || state.unit != unit {
+				continue
+			}
+			resp.Days[d].Scope += *state.estimate
+			if wf.IsDone(state.status) {
+				resp.Days[d].Completed += *state.estimate
+			}
+		}
+	}
+
+	for i := range resp.Days {
+		resp.Days[i].Remaining = resp.Days[i].Scope - resp.Days[i].Completed
+	}
+	start := resp.Days[0].Remaining
+	for i := range resp.Days {
+		if days == 1 {
+			resp.Days[i].Ideal = start
+			continue
+		}
+		resp.Days[i].Ideal = start * float64(days-1-i) / float64(days-1)
+	}
+	return c.JSON(resp)
+}
+
+// undo reverts the state to what it was before the history entry
+func (s *taskState) undo(a models.TaskActivity) {
+	switch a.Action {
+	case models.ActivityDeleted:
+		s.deleted = false
+	case models.ActivityRestored:
+		s.deleted = true
+	case models.ActivityUpdated:
+		switch a.Field {
+		case "status":
+			s.status = a.OldValue
+		case "estimate_unit":
+			s.unit = a.OldValue
+		case "estimate":
+			s.estimate = nil
+			if v, err := strconv.ParseFloat(a.OldValue, 64); err == nil {
+				s.estimate = &v
+			}
+		}
+	}
+}
+
+// burndownHistory loads the user's tasks, trashed ones included, with their history
+// since the start of the range, newest first per task
+func burndownHistory(db *gorm.DB, userID uint, projectID *uint, since time.Time) ([]models.Task, map[uint][]models.TaskActivity, error) {
+	var tasks []models.Task
+	var activities []models.TaskActivity
+
+	if db != nil {
+		query := db.Unscoped().Where("user_id = ?", userID)
+		if projectID != nil {
+			query = query.Where("project_id = ?", *projectID)
+		}
+		if err := query.Find(&tasks).Error; err != nil {
+			return nil, nil, err
+		}
+		ids := make([]uint, 0, len(tasks))
+		for _, t := range tasks {
+			ids = append(ids, t.ID)
+		}
+		if len(ids) > 0 {
+			err := db.Where("task_id IN ? AND created_at >= ?", ids, since).
+				Order("created_at DESC, id DESC").
+				Find(&activities).Error
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	} else {
+		// In-memory mode (fallback)
+		wanted := make(map[uint]bool)
+		for _, t := range models.Tasks {
+			if t.UserID == userID && (projectID == nil || (t.ProjectID != nil && *t.ProjectID == *projectID)) {
+				tasks = append(tasks, t)
+				wanted[t.ID] = true
+			}
+		}
+		for _, a := range models.TaskActivities {
+			if wanted[a.TaskID] && !a.CreatedAt.Before(since) {
+				activities = append(activities, a)
+			}
+		}
+		sort.SliceStable(activities, func(i, j int) bool {
+			if !activities[i].CreatedAt.Equal(activities[j].CreatedAt) {
+				return activities[i].CreatedAt.After(activities[j].CreatedAt)
+			}
+			return activities[i].ID > activities[j].ID
+		})
+	}
+
+	byTask := make(map[uint][]models.TaskActivity)
+	for _, a := range activities {
+		byTask[a.TaskID] = append(byTask[a.TaskID], a)
+	}
+	return tasks, byTask, nil
+}
+
+// parseBurndownDay reads a YYYY-MM-DD day as midnight UTC
+func parseBurndownDay(raw string, fallback time.Time) (time.Time, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	return time.Parse(customFieldDateLayout, raw)
+}
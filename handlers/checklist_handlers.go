@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	maxChecklistItems      = 100
+	maxChecklistTextLength = 500
+)
+
+// ChecklistItemCreateRequest kontrol listesi maddesi ekleme isteği modeli
+type ChecklistItemCreateRequest struct {
+	Text string `json:"text" example:"Erişim yetkilerini ver"`
+	Done bool   `json:"done" example:"false"`
+}
+
+// ChecklistItemUpdateRequest kontrol listesi maddesi güncelleme isteği modeli; gönderilmeyen alanlar değişmez
+type ChecklistItemUpdateRequest struct {
+	Text *string `json:"text,omitempty" example:"Erişim yetkilerini ver"`
+	Done *bool   `json:"done,omitempty" example:"true"`
+}
+
+// ChecklistItemMoveRequest kontrol listesi maddesini taşıma isteği modeli
+type ChecklistItemMoveRequest struct {
+	AfterID  *uint `json:"after_id,omitempty" example:"3"`  // Bu maddenin hemen arkasına yerleştir
+	BeforeID *uint `json:"before_id,omitempty" example:"2"` // Bu maddenin hemen önüne yerleştir
+}
+
+// TaskChecklistHandler görevin kontrol listesini döner
+// @ID TaskChecklistHandler
+// @Summary Kontrol listesini görüntüle
+// @Description Görevin kontrol listesi maddelerini sırasıyla döner
+// @Tags Checklists
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Success 200 {array} models.ChecklistItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/checklist [get]
+func TaskChecklistHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+
+	items, err := taskChecklist(taskDB(), task.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kontrol listesi alınamadı"})
+	}
+	return c.JSON(items)
+}
+
+// ChecklistItemCreateHandler görevin kontrol listesine madde ekler
+// @ID ChecklistItemCreateHandler
+// @Summary Kontrol listesine madde ekle
+// @Description Maddeyi kontrol listesinin sonuna ekler
+// @Tags Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param item body ChecklistItemCreateRequest true "Madde"
+// @Success 201 {object} models.ChecklistItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/checklist [post]
+func ChecklistItemCreateHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	var input ChecklistItemCreateRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	input.Text = strings.TrimSpace(input.Text)
+	if err := validateChecklistText(input.Text); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	if items, err := taskChecklist(taskDB(), task.ID); err == nil && len(items) >= maxChecklistItems {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Kontrol listesi en fazla %d madde içerebilir", maxChecklistItems)})
+	}
+
+	item := models.ChecklistItem{TaskID: task.ID, Text: input.Text, Done: input.Done}
+	if err := addChecklistItem(taskDB(), userID, &item); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Madde eklenemedi"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+// ChecklistItemUpdateHandler kontrol listesi maddesini günceller veya işaretler
+// @ID ChecklistItemUpdateHandler
+// @Summary Kontrol listesi maddesini güncelle
+// @Description Maddenin metnini ve/veya tamamlanma durumunu değiştirir. done kesin bir değer olarak gönderilir (true/false), bu yüzden aynı anda gelen işaretlemeler birbirini bozmaz.
+// @Tags Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param item_id path int true "Madde ID"
+// @Param item body ChecklistItemUpdateRequest true "Değişecek alanlar"
+// @Success 200 {object} models.ChecklistItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/checklist/{item_id} [patch]
+func ChecklistItemUpdateHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+	itemID, err := strconv.ParseUint(c.Params("item_id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz madde ID"})
+	}
+
+	var input ChecklistItemUpdateRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if input.Text != nil {
+		text := strings.TrimSpace(*input.Text)
+		if err := validateChecklistText(text); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		input.Text = &text
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	item, err := findChecklistItem(taskDB(), task.ID, uint(itemID))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Madde bulunamadı"})
+	}
+
+	if err := updateChecklistItem(taskDB(), userID, item, input.Text, input.Done); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Madde güncellenemedi"})
+	}
+	return c.JSON(item)
+}
+
+// ChecklistItemMoveHandler kontrol listesi maddesinin sırasını değiştirir
+// @ID ChecklistItemMoveHandler
+// @Summary Kontrol listesi maddesini taşı
+// @Description Maddeyi after_id maddesinin arkasına ya da before_id maddesinin önüne taşır; ikisi de verilmezse listenin sonuna koyar. Yalnızca taşınan madde yazılır. Güncel listeyi döner.
+// @Tags Checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param item_id path int true "Madde ID"
+// @Param request body ChecklistItemMoveRequest true "Hedef konum"
+// @Success 200 {array} models.ChecklistItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /tasks/{id}/checklist/{item_id}/move [post]
+func ChecklistItemMoveHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+	itemID, err := strconv.ParseUint(c.Params("item_id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz madde ID"})
+	}
+
+	var input ChecklistItemMoveRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if input.AfterID != nil && input.BeforeID != nil {
+		return validationFailed(c, fieldErrors{"before_id": "after_id ve before_id birlikte kullanılamaz"})
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	item, err := findChecklistItem(taskDB(), task.ID, uint(itemID))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Madde bulunamadı"})
+	}
+
+	if err := moveChecklistItem(taskDB(), item, input.AfterID, input.BeforeID); err != nil {
+		if errors.Is(err, errChecklistItemNotFound) {
+			field := "after_id"
+			if input.BeforeID != nil {
+				field = "before_id"
+			}
+			return validationFailed(c, fieldErrors{field: "Kontrol listesinde böyle bir madde yok"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Madde taşınamadı"})
+	}
+
+	items, err := taskChecklist(taskDB(), task.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kontrol listesi alınamadı"})
+	}
+	return c.JSON(items)
+}
+
+// ChecklistItemDeleteHandler kontrol listesi maddesini siler
+// @ID ChecklistItemDeleteHandler
+// @Summary Kontrol listesi maddesini sil
+// @Description Maddeyi görevin kontrol listesinden kaldırır
+// @Tags Checklists
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param item_id path int true "Madde ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/checklist/{item_id} [delete]
+func ChecklistItemDeleteHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+	itemID, err := strconv.ParseUint(c.Params("item_id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz madde ID"})
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	item, err := findChecklistItem(taskDB(), task.ID, uint(itemID))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Madde bulunamadı"})
+	}
+
+	if err := deleteChecklistItem(taskDB(), userID, item); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Madde silinemedi"})
+	}
+	return c.JSON(fiber.Map{"message": "Madde silindi"})
+}
+
+// validateChecklistText checks the text of a checklist item
+func validateChecklistText(text string) error {
+	if text == "" {
+		return errors.New("Madde metni zorunlu")
+	}
+	if utf8.RuneCountInString(text) > maxChecklistTextLength {
+		return fmt.Errorf("Madde metni en fazla %d karakter olabilir", maxChecklistTextLength)
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"go_taskmanagement/models"
+	"go_taskmanagement/rank"
+
+	"gorm.io/gorm"
+)
+
+var errChecklistItemNotFound = errors.New("checklist item not found")
+
+// checklistLine renders an item for the task history, like a markdown task list line
+func checklistLine(item models.ChecklistItem) string {
+	if item.Done {
+		return "[x] " + item.Text
+	}
+	return "[ ] " + item.Text
+}
+
+// taskChecklist returns the task's checklist in order
+func taskChecklist(db *gorm.DB, taskID uint) ([]models.ChecklistItem, error) {
+	items := []models.ChecklistItem{}
+	if db != nil {
+		err := db.Where("task_id = ?", taskID).Order("rank, id").Find(&items).Error
+		return items, err
+	}
+
+	// In-memory mode (fallback)
+	for _, item := range models.ChecklistItems {
+		if item.TaskID == taskID {
+			items = append(items, item)
+		}
+	}
+	// Items are stored in id order, so a stable sort matches the database order
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Rank < items[j].Rank })
+	return items, nil
+}
+
+// findChecklistItem loads an item of the task
+func findChecklistItem(db *gorm.DB, taskID, itemID uint) (*models.ChecklistItem, error) {
+	if db != nil {
+		var item models.ChecklistItem
+		if err := db.Where("id = ? AND task_id = ?", itemID, taskID).First(&item).Error; err != nil {
+			return nil, errChecklistItemNotFound
+		}
+		return &item, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.ChecklistItems {
+		if models.ChecklistItems[i].ID == itemID && models.ChecklistItems[i].TaskID == taskID {
+			return &models.ChecklistItems[i], nil
+		}
+	}
+	return nil, errChecklistItemNotFound
+}
+
+// checklistRank returns a rank that places an item right after afterID, right before
+// beforeID, or at the end of the checklist. itemID is the item being placed, if any.
+// With a database it takes a per-task lock, so db should be a transaction.
+func checklistRank(db *gorm.DB, taskID, itemID uint, afterID, beforeID *uint) (string, error) {
+	if db != nil {
+		if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext('task_checklist'), ?)", int32(taskID)).Error; err != nil {
+			return "", err
+		}
+	}
+	items, err := taskChecklist(db, taskID)
+	if err != nil {
+		return "", err
+	}
+
+	// Neighbours are looked up among the other items, in order
+	others := items[:0]
+	for _, item := range items {
+		if item.ID != itemID {
+			others = append(others, item)
+		}
+	}
+	at := len(others)
+	if afterID != nil || beforeID != nil {
+		at = -1
+		for i, item := range others {
+			if afterID != nil && item.ID == *afterID {
+				at = i + 1
+			}
+			if beforeID != nil && item.ID == *beforeID {
+				at = i
+			}
+		}
+		if at < 0 {
+			return "", errChecklistItemNotFound
+		}
+	}
+
+	var prev, next string
+	if at > 0 {
+		prev = others[at-1].Rank
+	}
+	if at < len(others) {
+		next = others[at].Rank
+	}
+	return rank.Between(prev, next), nil
+}
+
+// addChecklistItem appends the item to the task's checklist
+func addChecklistItem(db *gorm.DB, actorID uint, item *models.ChecklistItem) error {
+	if item.Done {
+		now := time.Now()
+		item.DoneAt = &now
+	}
+
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			r, err := checklistRank(tx, item.TaskID, 0, nil, nil)
+			if err != nil {
+				return err
+			}
+			item.Rank = r
+			if err := tx.Create(item).Error; err != nil {
+				return err
+			}
+			return recordTaskActivity(tx, item.TaskID, actorID, models.ActivityUpdated, "checklist", "", checklistLine(*item))
+		})
+	}
+
+	// In-memory mode (fallback)
+	r, err := checklistRank(nil, item.TaskID, 0, nil, nil)
+	if err != nil {
+		return err
+	}
+	item.Rank = r
+	item.ID = nextChecklistItemID()
+	item.CreatedAt = time.Now()
+	item.UpdatedAt = item.CreatedAt
+	models.ChecklistItems = append(models.ChecklistItems, *item)
+	return recordTaskActivity(nil, item.TaskID, actorID, models.ActivityUpdated, "checklist", "", checklistLine(*item))
+}
+
+// updateChecklistItem changes the text and/or done state of an item with a single
+// row update, so checking an item never races with other changes to the checklist
+func updateChecklistItem(db *gorm.DB, actorID uint, item *models.ChecklistItem, text *string, done *bool) error {
+	before := *item
+	updates := map[string]interface{}{}
+	if text != nil {
+		updates["text"] = *text
+	}
+	if done != nil && *done != item.Done {
+		updates["done"] = *done
+		updates["done_at"] = nil
+		if *done {
+			updates["done_at"] = time.Now()
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(item).Updates(updates).Error; err != nil {
+				return err
+			}
+			if err := tx.First(item, item.ID).Error; err != nil {
+				return err
+			}
+			if checklistLine(before) == checklistLine(*item) {
+				return nil
+			}
+			return recordTaskActivity(tx, item.TaskID, actorID, models.ActivityUpdated, "checklist", checklistLine(before), checklistLine(*item))
+		})
+	}
+
+	// In-memory mode (fallback)
+	if text != nil {
+		item.Text = *text
+	}
+	if v, ok := updates["done"]; ok {
+		item.Done = v.(bool)
+		item.DoneAt = nil
+		if item.Done {
+			now := time.Now()
+			item.DoneAt = &now
+		}
+	}
+	item.UpdatedAt = time.Now()
+	if checklistLine(before) == checklistLine(*item) {
+		return nil
+	}
+	return recordTaskActivity(nil, item.TaskID, actorID, models.ActivityUpdated, "checklist", checklistLine(before), checklistLine(*item))
+}
+
+// moveChecklistItem gives the item a new position; only the item itself is written
+func moveChecklistItem(db *gorm.DB, item *models.ChecklistItem, afterID, beforeID *uint) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			r, err := checklistRank(tx, item.TaskID, item.ID, afterID, beforeID)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(item).Update("rank", r).Error; err != nil {
+				return err
+			}
+			return tx.First(item, item.ID).Error
+		})
+	}
+
+	// In-memory mode (fallback)
+	r, err := checklistRank(nil, item.TaskID, item.ID, afterID, beforeID)
+	if err != nil {
+		return err
+	}
+	item.Rank = r
+	item.UpdatedAt = time.Now()
+	return nil
+}
+
+// deleteChecklistItem removes the item from its task
+func deleteChecklistItem(db *gorm.DB, actorID uint, item *models.ChecklistItem) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Delete(item).Error; err != nil {
+				return err
+			}
+			return recordTaskActivity(tx, item.TaskID, actorID, models.ActivityUpdated, "checklist", checklistLine(*item), "")
+		})
+	}
+
+	// In-memory mode (fallback)
+	removed := *item
+	for i := range models.ChecklistItems {
+		if models.ChecklistItems[i].ID == removed.ID {
+			models.ChecklistItems = append(models.ChecklistItems[:i], models.ChecklistItems[i+1:]...)
+			break
+		}
+	}
+	return recordTaskActivity(nil, removed.TaskID, actorID, models.ActivityUpdated, "checklist", checklistLine(removed), "")
+}
+
+// deleteTaskChecklist drops the task's checklist when it is purged in in-memory mode;
+// database.PurgeTasks does it in the database
+func deleteTaskChecklist(taskID uint) {
+	kept := models.ChecklistItems[:0]
+	for _, item := range models.ChecklistItems {
+		if item.TaskID != taskID {
+			kept = append(kept, item)
+		}
+	}
+	models.ChecklistItems = kept
+}
+
+// nextChecklistItemID returns a free id for the in-memory store
+func nextChecklistItemID() uint {
+	var max uint
+	for _, item := range models.ChecklistItems {
+		if item.ID > max {
+			max = item.ID
+		}
+	}
+	return max + 1
+}
+
+// setChecklistCounts fills in the done and total checklist counts of the tasks
+func setChecklistCounts(db *gorm.DB, tasks ...*models.Task) {
+	ids := make([]uint, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+
+	counts := make(map[uint]models.ChecklistSummary, len(ids))
+	if db != nil {
+		var rows []struct {
+			TaskID uint
+			Done   int
+			Total  int
+		}
+		err := db.Model(&models.ChecklistItem{}).
+			Select("task_id, COUNT(*) FILTER (WHERE done) AS done, COUNT(*) AS total").
+			Where("task_id IN ?", ids).
+			Group("task_id").
+			Scan(&rows).Error
+		if err != nil {
+			return
+		}
+		for _, r := range rows {
+			counts[r.TaskID] = models.ChecklistSummary{Done: r.Done, Total: r.Total}
+		}
+	} else {
+		// In-memory mode (fallback)
+		wanted := make(map[uint]bool, len(ids))
+		for _, id := range ids {
+			wanted[id] = true
+		}
+		for _, item := range models.ChecklistItems {
+			if !wanted[item.TaskID] {
+				continue
+			}
+			c := counts[item.TaskID]
+			c.Total++
+			if item.Done {
+				c.Done++
+			}
+			counts[item.TaskID] = c
+		}
+	}
+
+	for _, t := range tasks {
+		t.Checklist = counts[t.ID]
+	}
+}
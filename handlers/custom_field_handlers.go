@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CustomFieldCreateRequest özel alan tanımı oluşturma isteği modeli
+type CustomFieldCreateRequest struct {
+	Key      string   `json:"key" example:"story_points"`
+	Name     string   `json:"name" example:"Story points"`
+	Type     string   `json:"type" enums:"number,date,single_select,multi_select,text,user" example:"number"`
+	Options  []string `json:"options,omitempty"` // single_select ve multi_select için zorunlu
+	Required bool     `json:"required" example:"false"`
+}
+
+// CustomFieldsListHandler projenin özel alan tanımlarını listeler
+// @ID CustomFieldsListHandler
+// @Summary Özel alanları listele
+// @Description Projedeki görevlerin taşıyabileceği özel alan tanımlarını döner
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Proje ID"
+// @Success 200 {array} models.CustomField
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/fields [get]
+func CustomFieldsListHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz proje ID"})
+	}
+
+	project, err := findUserProject(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Proje bulunamadı veya yetkiniz yok"})
+	}
+
+	fields, err := projectCustomFields(taskDB(), project.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Alanlar alınamadı"})
+	}
+	return c.JSON(fields)
+}
+
+// CustomFieldCreateHandler projeye özel alan tanımı ekler
+// @ID CustomFieldCreateHandler
+// @Summary Özel alan ekle
+// @Description Projeye tipli bir özel alan ekler. Anahtar küçük harf, rakam ve alt çizgiden oluşur ve proje içinde benzersizdir.
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Proje ID"
+// @Param field body CustomFieldCreateRequest true "Alan tanımı"
+// @Success 201 {object} models.CustomField
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /projects/{id}/fields [post]
+func CustomFieldCreateHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz proje ID"})
+	}
+
+	var input CustomFieldCreateRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if errs := validateCustomFieldDefinition(&input); len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	project, err := findUserProject(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Proje bulunamadı veya yetkiniz yok"})
+	}
+
+	field := models.CustomField{
+		ProjectID: project.ID,
+		Key:       input.Key,
+		Name:      input.Name,
+		Type:      input.Type,
+		Options:   input.Options,
+		Required:  input.Required,
+	}
+	if err := createCustomField(taskDB(), &field); err != nil {
+		if errors.Is(err, errCustomFieldExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Bu anahtarla bir alan zaten var"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Alan oluşturulamadı"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(field)
+}
+
+// CustomFieldDeleteHandler projeden özel alan tanımını siler
+// @ID CustomFieldDeleteHandler
+// @Summary Özel alanı sil
+// @Description Alan tanımını ve projedeki görevlerde bu alana ait değerleri siler
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Proje ID"
+// @Param key path string true "Alan anahtarı"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/fields/{key} [delete]
+func CustomFieldDeleteHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz proje ID"})
+	}
+
+	project, err := findUserProject(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Proje bulunamadı veya yetkiniz yok"})
+	}
+
+	if err := deleteCustomField(taskDB(), project.ID, c.Params("key")); err != nil {
+		if errors.Is(err, errCustomFieldNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alan bulunamadı"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Alan silinemedi"})
+	}
+	return c.JSON(fiber.Map{"message": "Alan silindi"})
+}
+
+// validateCustomFieldDefinition checks a new field definition and trims its text
+func validateCustomFieldDefinition(input *CustomFieldCreateRequest) fieldErrors {
+	errs := fieldErrors{}
+	if !customFieldKeyPattern.MatchString(input.Key) {
+		errs["key"] = "Anahtar küçük harfle başlamalı, yalnızca küçük harf, rakam ve _ içermeli (en fazla 50 karakter)"
+	}
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		errs["name"] = "Alan adı zorunlu"
+	}
+	if !slices.Contains(models.CustomFieldTypes, input.Type) {
+		errs["type"] = "Geçersiz alan tipi, izin verilenler: " + strings.Join(models.CustomFieldTypes, ", ")
+		return errs
+	}
+
+	isSelect := input.Type == models.CustomFieldSingleSelect || input.Type == models.CustomFieldMultiSelect
+	switch {
+	case isSelect && len(input.Options) == 0:
+		errs["options"] = "Seçim alanları için seçenekler zorunlu"
+	case !isSelect && len(input.Options) > 0:
+		errs["options"] = "Seçenekler yalnızca seçim alanlarında kullanılabilir"
+	}
+	for i, opt := range input.Options {
+		if opt == "" || slices.Contains(input.Options[:i], opt) {
+			errs["options"] = "Seçenekler boş olmamalı ve tekrar etmemeli"
+			break
+		}
+	}
+	return errs
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	errCustomFieldNotFound = errors.New("custom field not found")
+	errCustomFieldExists   = errors.New("custom field already exists")
+)
+
+// projectCustomFields returns the field definitions of a project in creation order
+func projectCustomFields(db *gorm.DB, projectID uint) ([]models.CustomField, error) {
+	fields := []models.CustomField{}
+	if db != nil {
+		err := db.Where("project_id = ?", projectID).Order("id").Find(&fields).Error
+		return fields, err
+	}
+
+	// In-memory mode (fallback)
+	for _, f := range models.CustomFields {
+		if f.ProjectID == projectID {
+			fields = append(fields, f)
+		}
+	}
+	return fields, nil
+}
+
+// createCustomField stores a new field definition; keys are unique per project
+func createCustomField(db *gorm.DB, field *models.CustomField) error {
+	if db != nil {
+		var count int64
+		db.Model(&models.CustomField{}).Where("project_id = ? AND key = ?", field.ProjectID, field.Key).Count(&count)
+		if count > 0 {
+			return errCustomFieldExists
+		}
+		return db.Create(field).Error
+	}
+
+	// In-memory mode (fallback)
+	for _, f := range models.CustomFields {
+		if f.ProjectID == field.ProjectID && f.Key == field.Key {
+			return errCustomFieldExists
+		}
+	}
+	field.ID = uint(len(models.CustomFields) + 1)
+	field.CreatedAt = time.Now()
+	models.CustomFields = append(models.CustomFields, *field)
+	return nil
+}
+
+// deleteCustomField removes a field definition together with its values on the project's tasks.
+// Tasks that lose a value get a new version so cached ETags stop matching.
+func deleteCustomField(db *gorm.DB, projectID uint, key string) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Where("project_id = ? AND key = ?", projectID, key).Delete(&models.CustomField{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errCustomFieldNotFound
+			}
+			return tx.Unscoped().Model(&models.Task{}).
+				Where("project_id = ? AND custom_fields -> ? IS NOT NULL", projectID, key).
+				Updates(map[string]interface{}{
+					"custom_fields": gorm.Expr("custom_fields - ?", key),
+					"version":       gorm.Expr("version + 1"),
+				}).Error
+		})
+	}
+
+	// In-memory mode (fallback)
+	found := false
+	for i, f := range models.CustomFields {
+		if f.ProjectID == projectID && f.Key == key {
+			models.CustomFields = append(models.CustomFields[:i], models.CustomFields[i+1:]...)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errCustomFieldNotFound
+	}
+	for i := range models.Tasks {
+		t := &models.Tasks[i]
+		if t.ProjectID == nil || *t.ProjectID != projectID {
+			continue
+		}
+		if _, ok := t.CustomFields[key]; ok {
+			values := make(models.CustomFieldValues, len(t.CustomFields))
+			for k, v := range t.CustomFields {
+				if k != key {
+					values[k] = v
+				}
+			}
+			t.CustomFields = values
+			t.Version++
+		}
+	}
+	return nil
+}
+
+// userExists reports whether a user with the id exists, for user reference fields
+func userExists(db *gorm.DB, id uint) bool {
+	if db != nil {
+		var count int64
+		db.Model(&models.User{}).Where("id = ?", id).Count(&count)
+		return count > 0
+	}
+
+	// In-memory mode (fallback)
+	for _, u := range models.Users {
+		if u.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	customFieldDateLayout    = "2006-01-02"
+	maxCustomFieldTextLength = 1000
+	customFieldQueryPrefix   = "cf."
+)
+
+// customFieldKeyPattern keeps keys safe to use as JSON paths in SQL
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// validateTaskCustomFields checks the complete set of custom field values a task
+// would have against its project's definitions and returns them normalized
+func validateTaskCustomFields(db *gorm.DB, task *models.Task, values models.CustomFieldValues) (models.CustomFieldValues, fieldErrors) {
+	if task.ProjectID == nil {
+		if len(values) > 0 {
+			return nil, fieldErrors{"custom_fields": "Özel alanlar yalnızca bir projeye ait görevlerde kullanılabilir"}
+		}
+		return values, nil
+	}
+
+	defs, err := projectCustomFields(db, *task.ProjectID)
+	if err != nil {
+		return nil, fieldErrors{"custom_fields": "Proje alanları alınamadı"}
+	}
+
+	errs := fieldErrors{}
+	normalized := make(models.CustomFieldValues, len(values))
+	known := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		known[def.Key] = true
+		value, ok := values[def.Key]
+		if !ok || value == nil {
+			if def.Required {
+				errs["custom_fields."+def.Key] = "Zorunlu alan"
+			}
+			continue
+		}
+		v, err := normalizeCustomFieldValue(db, def, value)
+		if err != nil {
+			errs["custom_fields."+def.Key] = err.Error()
+			continue
+		}
+		normalized[def.Key] = v
+	}
+	for key := range values {
+		if !known[key] {
+			errs["custom_fields."+key] = "Bilinmeyen özel alan"
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+	return normalized, nil
+}
+
+// normalizeCustomFieldValue checks a decoded JSON value against the field type.
+// Values already normalized in memory ([]string, uint) are accepted too, since
+// a merge patch re-checks the stored values it keeps.
+func normalizeCustomFieldValue(db *gorm.DB, def models.CustomField, value interface{}) (interface{}, error) {
+	switch def.Type {
+	case models.CustomFieldNumber:
+		if n, ok := value.(float64); ok {
+			return n, nil
+		}
+		return nil, errors.New("Sayı olmalı")
+
+	case models.CustomFieldDate:
+		if s, ok := value.(string); ok {
+			if _, err := time.Parse(customFieldDateLayout, s); err == nil {
+				return s, nil
+			}
+		}
+		return nil, errors.New("YYYY-MM-DD biçiminde tarih olmalı")
+
+	case models.CustomFieldText:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("Metin olmalı")
+		}
+		if utf8.RuneCountInString(s) > maxCustomFieldTextLength {
+			return nil, fmt.Errorf("En fazla %d karakter olabilir", maxCustomFieldTextLength)
+		}
+		return s, nil
+
+	case models.CustomFieldSingleSelect:
+		if s, ok := value.(string); ok && slices.Contains(def.Options, s) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("Şunlardan biri olmalı: %s", strings.Join(def.Options, ", "))
+
+	case models.CustomFieldMultiSelect:
+		var items []interface{}
+		switch v := value.(type) {
+		case []interface{}:
+			items = v
+		case []string:
+			for _, item := range v {
+				items = append(items, item)
+			}
+		default:
+			return nil, errors.New("Liste olmalı")
+		}
+		selected := make([]string, 0, len(items))
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok || !slices.Contains(def.Options, s) {
+				return nil, fmt.Errorf("Değerler şunlardan olmalı: %s", strings.Join(def.Options, ", "))
+			}
+			if slices.Contains(selected, s) {
+				return nil, fmt.Errorf("%s birden fazla seçilmiş", s)
+			}
+			selected = append(selected, s)
+		}
+		return selected, nil
+
+	case models.CustomFieldUser:
+		if id, ok := value.(uint); ok {
+			value = float64(id)
+		}
+		n, ok := value.(float64)
+		if !ok || n < 1 || n != math.Trunc(n) {
+			return nil, errors.New("Kullanıcı ID olmalı")
+		}
+		if !userExists(db, uint(n)) {
+			return nil, errors.New("Kullanıcı bulunamadı")
+		}
+		return uint(n), nil
+	}
+	return nil, errors.New("Bilinmeyen alan tipi")
+}
+
+// mergeCustomFields applies a merge patch to the task's custom field values:
+// members set a value, null members remove it and a null document clears all
+func mergeCustomFields(current models.CustomFieldValues, raw json.RawMessage) (models.CustomFieldValues, error) {
+	if string(raw) == "null" {
+		return models.CustomFieldValues{}, nil
+	}
+	var patch map[string]interface{}
+	if err := json.Unmarshal(raw, &patch); err != nil || patch == nil {
+		return nil, errors.New("custom_fields alanı nesne olmalı")
+	}
+	merged := make(models.CustomFieldValues, len(current)+len(patch))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(merged, k)
+		} else {
+			merged[k] = v
+		}
+	}
+	return merged, nil
+}
+
+// customFieldChanges lists the keys whose value differs, as JSON for the task history
+func customFieldChanges(before, after models.CustomFieldValues) []struct{ field, old, new string } {
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var changes []struct{ field, old, new string }
+	for _, k := range keys {
+		old, new := customFieldJSON(before, k), customFieldJSON(after, k)
+		if old != new {
+			changes = append(changes, struct{ field, old, new string }{"custom_fields." + k, old, new})
+		}
+	}
+	return changes
+}
+
+func customFieldJSON(values models.CustomFieldValues, key string) string {
+	v, ok := values[key]
+	if !ok {
+		return ""
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
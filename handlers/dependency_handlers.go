@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// TaskDependencyRequest bağımlılık ekleme isteği modeli
+type TaskDependencyRequest struct {
+	BlockerID uint `json:"blocker_id" example:"2"`
+}
+
+// TaskDependenciesResponse görevin bağımlılıkları
+type TaskDependenciesResponse struct {
+	BlockedBy []models.Task `json:"blocked_by"` // Bu görevi engelleyen görevler
+	Blocks    []models.Task `json:"blocks"`     // Bu görevin engellediği görevler
+}
+
+// TaskDependenciesHandler görevin bağımlılıklarını döner
+// @ID TaskDependenciesHandler
+// @Summary Görev bağımlılıklarını listele
+// @Description Görevi engelleyen ve görevin engellediği görevleri döner
+// @Tags Tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Success 200 {object} TaskDependenciesResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/dependencies [get]
+func TaskDependenciesHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+
+	return taskDependenciesResponse(c, fiber.StatusOK, task.ID)
+}
+
+// TaskDependencyAddHandler göreve engelleyen görev ekler
+// @ID TaskDependencyAddHandler
+// @Summary Bağımlılık ekle
+// @Description blocker_id ile verilen görevin bu görevi engellediğini kaydeder. Döngü oluşturan bağımlılıklar reddedilir.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Engellenen görev ID"
+// @Param request body TaskDependencyRequest true "Engelleyen görev"
+// @Success 201 {object} TaskDependenciesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /tasks/{id}/dependencies [post]
+func TaskDependencyAddHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	var input TaskDependencyRequest
+	if err := c.BodyParser(&input); err != nil || input.BlockerID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "blocker_id zorunlu"})
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	if input.BlockerID == task.ID {
+		return validationFailed(c, fieldErrors{"blocker_id": "Görev kendini engelleyemez"})
+	}
+	blocker, err := findUserTask(taskDB(), userID, input.BlockerID)
+	if err != nil {
+		return validationFailed(c, fieldErrors{"blocker_id": "Engelleyen görev bulunamadı veya yetkiniz yok"})
+	}
+
+	if err := addTaskDependency(taskDB(), userID, blocker, task); err != nil {
+		var cycle *dependencyCycleError
+		switch {
+		case errors.As(err, &cycle):
+			return validationFailed(c, fieldErrors{"blocker_id": cycle.Error()})
+		case errors.Is(err, errDependencyExists):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Bu bağımlılık zaten var"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Bağımlılık eklenemedi"})
+	}
+
+	return taskDependenciesResponse(c, fiber.StatusCreated, task.ID)
+}
+
+// TaskDependencyRemoveHandler görevden engelleyen görevi kaldırır
+// @ID TaskDependencyRemoveHandler
+// @Summary Bağımlılığı kaldır
+// @Description Verilen görevin bu görevi engellemesini kaldırır
+// @Tags Tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Engellenen görev ID"
+// @Param blocker_id path int true "Engelleyen görev ID"
+// @Success 200 {object} TaskDependenciesResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/dependencies/{blocker_id} [delete]
+func TaskDependencyRemoveHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+	blockerID, err := strconv.ParseUint(c.Params("blocker_id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+
+	if err := removeTaskDependency(taskDB(), userID, uint(blockerID), task.ID); err != nil {
+		if errors.Is(err, errDependencyNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Bağımlılık bulunamadı"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Bağımlılık kaldırılamadı"})
+	}
+
+	return taskDependenciesResponse(c, fiber.StatusOK, task.ID)
+}
+
+// taskDependenciesResponse writes both sides of the task's dependencies with their blocked flags
+func taskDependenciesResponse(c *fiber.Ctx, status int, taskID uint) error {
+	blockedBy, blocks, err := taskDependencyTasks(taskDB(), taskID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Bağımlılıklar alınamadı"})
+	}
+	setComputedFields(taskDB(), taskPointers(blockedBy)...)
+	setComputedFields(taskDB(), taskPointers(blocks)...)
+	return c.Status(status).JSON(TaskDependenciesResponse{BlockedBy: blockedBy, Blocks: blocks})
+}
+
+// validateTaskBlockers refuses to move a task into a done status while tasks
+// blocking it are still open, unless the caller forces it
+func validateTaskBlockers(db *gorm.DB, task *models.Task, updates map[string]interface{}, force bool) fieldErrors {
+	status, ok := updates["status"].(string)
+	if !ok || force {
+		return nil
+	}
+	wf := taskWorkflow(task)
+	if !wf.IsDone(status) || wf.IsDone(task.Status) {
+		return nil
+	}
+
+	open, err := openBlockers(db, []uint{task.ID})
+	if err != nil {
+		return fieldErrors{"status": "Görevi engelleyen görevler kontrol edilemedi"}
+	}
+	if len(open[task.ID]) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(open[task.ID]))
+	for _, id := range open[task.ID] {
+		ids = append(ids, fmt.Sprintf("#%d", id))
+	}
+	return fieldErrors{"status": fmt.Sprintf("Görevi engelleyen açık görevler var: %s (yine de tamamlamak için force=true)", strings.Join(ids, ", "))}
+}
+
+// taskPointers returns pointers to the elements of tasks
+func taskPointers(tasks []models.Task) []*models.Task {
+	ptrs := make([]*models.Task, len(tasks))
+	for i := range tasks {
+		ptrs[i] = &tasks[i]
+	}
+	return ptrs
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go_taskmanagement/models"
+	"go_taskmanagement/workflow"
+
+	"gorm.io/gorm"
+)
+
+var (
+	errDependencyExists   = errors.New("dependency already exists")
+	errDependencyNotFound = errors.New("dependency not found")
+)
+
+// dependencyCycleError is returned when a new dependency would close a loop.
+// Path runs from the blocked task back to the blocker through existing dependencies.
+type dependencyCycleError struct {
+	Path []uint
+}
+
+func (e *dependencyCycleError) Error() string {
+	parts := make([]string, 0, len(e.Path)+1)
+	for _, id := range e.Path {
+		parts = append(parts, fmt.Sprintf("#%d", id))
+	}
+	parts = append(parts, parts[0])
+	return "Bağımlılık döngü oluşturur: " + strings.Join(parts, " → ")
+}
+
+// dependencyEdges returns the dependencies whose blocker is one of the given tasks
+func dependencyEdges(db *gorm.DB, blockerIDs []uint) ([]models.TaskDependency, error) {
+	if db != nil {
+		var edges []models.TaskDependency
+		err := db.Where("blocker_id IN ?", blockerIDs).Find(&edges).Error
+		return edges, err
+	}
+
+	// In-memory mode (fallback)
+	wanted := make(map[uint]bool, len(blockerIDs))
+	for _, id := range blockerIDs {
+		wanted[id] = true
+	}
+	var edges []models.TaskDependency
+	for _, d := range models.TaskDependencies {
+		if wanted[d.BlockerID] {
+			edges = append(edges, d)
+		}
+	}
+	return edges, nil
+}
+
+// dependencyPath searches the "blocks" graph breadth-first and returns the
+// shortest chain of tasks from one task to another, or nil when there is none
+func dependencyPath(db *gorm.DB, from, to uint) ([]uint, error) {
+	parent := map[uint]uint{from: from}
+	frontier := []uint{from}
+	for len(frontier) > 0 {
+		edges, err := dependencyEdges(db, frontier)
+		if err != nil {
+			return nil, err
+		}
+		frontier = frontier[:0]
+		for _, e := range edges {
+			if _, seen := parent[e.BlockedID]; seen {
+				continue
+			}
+			parent[e.BlockedID] = e.BlockerID
+			if e.BlockedID == to {
+				path := []uint{to}
+				for id := to; id != from; {
+					id = parent[id]
+					path = append([]uint{id}, path...)
+				}
+				return path, nil
+			}
+			frontier = append(frontier, e.BlockedID)
+		}
+	}
+	return nil, nil
+}
+
+// addTaskDependency records that blocker blocks blocked, rejecting duplicates and cycles.
+// Writes are serialized per user so two concurrent requests cannot close a loop together.
+func addTaskDependency(db *gorm.DB, actorID uint, blocker, blocked *models.Task) error {
+	add := func(tx *gorm.DB) error {
+		path, err := dependencyPath(tx, blocked.ID, blocker.ID)
+		if err != nil {
+			return err
+		}
+		if path != nil {
+			return &dependencyCycleError{Path: path}
+		}
+		return recordTaskActivity(tx, blocked.ID, actorID, models.ActivityUpdated, "blocked_by", "", fmt.Sprint(blocker.ID))
+	}
+
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('task_dependencies'), ?)", int32(blocked.UserID)).Error; err != nil {
+				return err
+			}
+			var count int64
+			tx.Model(&models.TaskDependency{}).Where("blocker_id = ? AND blocked_id = ?", blocker.ID, blocked.ID).Count(&count)
+			if count > 0 {
+				return errDependencyExists
+			}
+			if err := add(tx); err != nil {
+				return err
+			}
+			return tx.Create(&models.TaskDependency{BlockerID: blocker.ID, BlockedID: blocked.ID}).Error
+		})
+	}
+
+	// In-memory mode (fallback)
+	for _, d := range models.TaskDependencies {
+		if d.BlockerID == blocker.ID && d.BlockedID == blocked.ID {
+			return errDependencyExists
+		}
+	}
+	if err := add(nil); err != nil {
+		return err
+	}
+	models.TaskDependencies = append(models.TaskDependencies, models.TaskDependency{
+		ID:        nextTaskDependencyID(),
+		BlockerID: blocker.ID,
+		BlockedID: blocked.ID,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+// nextTaskDependencyID returns a free id for the in-memory store; ids stay unique after removals
+func nextTaskDependencyID() uint {
+	var max uint
+	for _, d := range models.TaskDependencies {
+		if d.ID > max {
+			max = d.ID
+		}
+	}
+	return max + 1
+}
+
+// removeTaskDependency deletes the dependency between the two tasks
+func removeTaskDependency(db *gorm.DB, actorID, blockerID, blockedID uint) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Delete(&models.TaskDependency{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errDependencyNotFound
+			}
+			return recordTaskActivity(tx, blockedID, actorID, models.ActivityUpdated, "blocked_by", fmt.Sprint(blockerID), "")
+		})
+	}
+
+	// In-memory mode (fallback)
+	for i, d := range models.TaskDependencies {
+		if d.BlockerID == blockerID && d.BlockedID == blockedID {
+			models.TaskDependencies = append(models.TaskDependencies[:i], models.TaskDependencies[i+1:]...)
+			return recordTaskActivity(nil, blockedID, actorID, models.ActivityUpdated, "blocked_by", fmt.Sprint(blockerID), "")
+		}
+	}
+	return errDependencyNotFound
+}
+
+// deleteTaskDependencies drops every dependency the task takes part in when it is purged
+// in in-memory mode; database.PurgeTasks does it in the database
+func deleteTaskDependencies(taskID uint) {
+	kept := models.TaskDependencies[:0]
+	for _, d := range models.TaskDependencies {
+		if d.BlockerID != taskID && d.BlockedID != taskID {
+			kept = append(kept, d)
+		}
+	}
+	models.TaskDependencies = kept
+}
+
+// taskDependencyTasks loads the tasks that block the task and the tasks it blocks.
+// Trashed tasks are left out.
+func taskDependencyTasks(db *gorm.DB, taskID uint) (blockedBy, blocks []models.Task, err error) {
+	blockedBy, blocks = []models.Task{}, []models.Task{}
+	if db != nil {
+		err = db.Preload("User").
+			Where("id IN (?)", db.Model(&models.TaskDependency{}).Select("blocker_id").Where("blocked_id = ?", taskID)).
+			Order("id").Find(&blockedBy).Error
+		if err != nil {
+			return nil, nil, err
+		}
+		err = db.Preload("User").
+			Where("id IN (?)", db.Model(&models.TaskDependency{}).Select("blocked_id").Where("blocker_id = ?", taskID)).
+			Order("id").Find(&blocks).Error
+		return blockedBy, blocks, err
+	}
+
+	// In-memory mode (fallback)
+	for _, d := range models.TaskDependencies {
+		for _, t := range models.Tasks {
+			if t.DeletedAt.Valid {
+				continue
+			}
+			if d.BlockedID == taskID && t.ID == d.BlockerID {
+				blockedBy = append(blockedBy, t)
+			}
+			if d.BlockerID == taskID && t.ID == d.BlockedID {
+				blocks = append(blocks, t)
+			}
+		}
+	}
+	return blockedBy, blocks, nil
+}
+
+// openBlockers returns, for each of the given tasks, the ids of its blockers that
+// are not in a done status of their own workflow. Trashed blockers don't count.
+func openBlockers(db *gorm.DB, taskIDs []uint) (map[uint][]uint, error) {
+	open := make(map[uint][]uint)
+	if len(taskIDs) == 0 {
+		return open, nil
+	}
+
+	var deps []models.TaskDependency
+	var blockers []models.Task
+	if db != nil {
+		if err := db.Where("blocked_id IN ?", taskIDs).Order("blocker_id").Find(&deps).Error; err != nil {
+			return nil, err
+		}
+		if len(deps) == 0 {
+			return open, nil
+		}
+		ids := make([]uint, 0, len(deps))
+		for _, d := range deps {
+			ids = append(ids, d.BlockerID)
+		}
+		if err := db.Select("id", "status", "project_id").Where("id IN ?", ids).Find(&blockers).Error; err != nil {
+			return nil, err
+		}
+	} else {
+		// In-memory mode (fallback)
+		wanted := make(map[uint]bool, len(taskIDs))
+		for _, id := range taskIDs {
+			wanted[id] = true
+		}
+		for _, d := range models.TaskDependencies {
+			if wanted[d.BlockedID] {
+				deps = append(deps, d)
+			}
+		}
+		for _, t := range models.Tasks {
+			if !t.DeletedAt.Valid {
+				blockers = append(blockers, t)
+			}
+		}
+	}
+
+	workflows := make(map[uint]*workflow.Workflow)
+	isOpen := make(map[uint]bool, len(blockers))
+	for i := range blockers {
+		b := &blockers[i]
+		var wf *workflow.Workflow
+		if b.ProjectID == nil {
+			wf = workflow.Default()
+		} else if wf = workflows[*b.ProjectID]; wf == nil {
+			wf = taskWorkflow(b)
+			workflows[*b.ProjectID] = wf
+		}
+		isOpen[b.ID] = !wf.IsDone(b.Status)
+	}
+	for _, d := range deps {
+		if isOpen[d.BlockerID] {
+			open[d.BlockedID] = append(open[d.BlockedID], d.BlockerID)
+		}
+	}
+	return open, nil
+}
+
+// setBlockedFlags fills in the computed Blocked field of the tasks
+func setBlockedFlags(db *gorm.DB, tasks ...*models.Task) {
+	ids := make([]uint, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	open, err := openBlockers(db, ids)
+	if err != nil {
+		return
+	}
+	for _, t := range tasks {
+		t.Blocked = len(open[t.ID]) > 0
+	}
+}
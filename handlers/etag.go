@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// taskETag builds a strong entity tag from the task version and its computed
+// fields. Those change with dependencies, time entries and checklist items
+// without a new version, so they are hashed into the tag; callers must run
+// setComputedFields first.
+func taskETag(task *models.Task) string {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%t|%d|%d/%d|%d/%d", task.Blocked, task.TrackedSeconds,
+		task.Checklist.Done, task.Checklist.Total, task.DescriptionTasks.Done, task.DescriptionTasks.Total)
+	return fmt.Sprintf(`"%d-%08x"`, task.Version, h.Sum32())
+}
+
+// etagMatches reports whether the header value lists the given tag or "*".
+// Weak comparison ignores the W/ prefix and is used for If-None-Match,
+// strong comparison is used for If-Match as required by RFC 9110.
+func etagMatches(header, etag string, strong bool) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if strong {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchSatisfied checks the If-Match precondition; a missing header always passes
+func ifMatchSatisfied(c *fiber.Ctx, task *models.Task) bool {
+	header := c.Get(fiber.HeaderIfMatch)
+	if header == "" {
+		return true
+	}
+	setComputedFields(taskDB(), task)
+	return etagMatches(header, taskETag(task), true)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"go_taskmanagement/lockout"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// loginAttempts holds the failed login counts in in-memory mode
+var loginAttempts = lockout.NewMemoryStore()
+
+// loginAttemptStore returns the store matching the storage mode
+func loginAttemptStore(db *gorm.DB) lockout.Store {
+	if db != nil {
+		return lockout.NewPostgresStore(db)
+	}
+	return loginAttempts
+}
+
+// loginGuards returns the account and IP guards; policies are read from the environment
+func loginGuards(db *gorm.DB) (account, ip lockout.Guard) {
+	store := loginAttemptStore(db)
+	return lockout.Guard{Store: store, Policy: lockout.AccountPolicy()}, lockout.Guard{Store: store, Policy: lockout.IPPolicy()}
+}
+
+// loginAttempt is a login attempt counted against the account and the client IP before
+// the password is checked, so concurrent guesses can't all slip under the limit. The
+// handler settles it with failed, succeeded or released.
+type loginAttempt struct {
+	c      *fiber.Ctx
+	db     *gorm.DB
+	checks []loginCheck
+}
+
+type loginCheck struct {
+	guard   lockout.Guard
+	key     string
+	attempt models.LoginAttempt
+	account bool
+}
+
+// beginLogin answers 429 when the account or the client IP has to wait before trying
+// again, and otherwise reserves the attempt. Unknown emails are tracked like real ones,
+// so the answer says nothing about whether an account exists. When it reports true the
+// caller returns the error.
+func beginLogin(c *fiber.Ctx, db *gorm.DB, email string) (*loginAttempt, bool, error) {
+	account, ip := loginGuards(db)
+	a := &loginAttempt{c: c, db: db, checks: []loginCheck{
+		{guard: account, key: lockout.Key(lockout.KindAccount, email), account: true},
+		{guard: ip, key: lockout.Key(lockout.KindIP, c.IP())},
+	}}
+	now := time.Now()
+	if wait, locked, err := a.wait(now); err != nil || wait > 0 {
+		return nil, true, a.throttled(wait, locked, err)
+	}
+
+	for i := range a.checks {
+		check := &a.checks[i]
+		attempt, ok, err := check.guard.Reserve(check.key, now)
+		if err != nil {
+			a.checks = a.checks[:i]
+			a.release()
+			return nil, true, a.throttled(0, false, err)
+		}
+		check.attempt = attempt
+		if !ok {
+			// Concurrent attempts took the last tries; refuse this one unchecked
+			a.checks = a.checks[:i+1]
+			a.release()
+			wait, _, _ := check.guard.Wait(check.key, now)
+			return nil, true, a.throttled(max(wait, time.Second), true, nil)
+		}
+	}
+	return a, false, nil
+}
+
+// wait returns the longest wait of the account and the IP, and whether one is locked
+func (a *loginAttempt) wait(now time.Time) (time.Duration, bool, error) {
+	var wait time.Duration
+	var locked bool
+	for _, check := range a.checks {
+		w, l, err := check.guard.Wait(check.key, now)
+		if err != nil {
+			return 0, false, err
+		}
+		if w > wait {
+			wait = w
+		}
+		locked = locked || l
+	}
+	return wait, locked, nil
+}
+
+// throttled writes the 429 (or the 500 for a store error) that refuses the attempt
+func (a *loginAttempt) throttled(wait time.Duration, locked bool, err error) error {
+	if err != nil {
+		return a.c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Giriş yapılamadı"})
+	}
+	a.c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	msg := "Çok fazla başarısız giriş denemesi, lütfen biraz bekleyip tekrar deneyin"
+	if locked {
+		msg = "Çok fazla başarısız giriş denemesi, girişler geçici olarak kilitlendi"
+	}
+	return a.c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": msg})
+}
+
+// failed settles a wrong email, password or code: the reserved failures stay, and each
+// lockout they cause gets an audit entry. user is nil for unknown emails.
+func (a *loginAttempt) failed(user *models.User) {
+	now := time.Now()
+	for _, check := range a.checks {
+		attempt, locked, err := check.guard.Fail(check.key, check.attempt, now)
+		if err != nil {
+			log.Printf("Failed to lock %s: %v", check.key, err)
+			continue
+		}
+		if !locked {
+			continue
+		}
+		entry := models.AuditLog{
+			Action:  models.AuditLoginLocked,
+			Subject: check.key,
+			IP:      a.c.IP(),
+			Detail:  fmt.Sprintf("%d başarısız giriş, kilit bitişi %s", attempt.Failures, attempt.LockedUntil.UTC().Format(time.RFC3339)),
+		}
+		if check.account && user != nil {
+			entry.UserID = &user.ID
+		}
+		if err := recordAudit(a.db, entry); err != nil {
+			log.Printf("Failed to write audit log for %s: %v", check.key, err)
+		}
+	}
+}
+
+// succeeded settles a completed login: the account's failures are forgotten. The IP
+// count only gets its reservation back, so logging into one account doesn't buy more
+// guesses at others.
+func (a *loginAttempt) succeeded() {
+	for _, check := range a.checks {
+		var err error
+		if check.account {
+			err = check.guard.Store.Reset(check.key)
+		} else {
+			err = check.guard.Store.Release(check.key)
+		}
+		if err != nil {
+			log.Printf("Failed to settle login attempt for %s: %v", check.key, err)
+		}
+	}
+}
+
+// release gives back the reservations of an attempt that neither failed nor completed
+// the login, like a right password still waiting for its second factor
+func (a *loginAttempt) release() {
+	for _, check := range a.checks {
+		if err := check.guard.Store.Release(check.key); err != nil {
+			log.Printf("Failed to release login attempt for %s: %v", check.key, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bytes"
+	"regexp"
+
+	"go_taskmanagement/models"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// Task descriptions are CommonMark with GitHub style task list items ("- [ ] ...").
+// Raw HTML in the source is dropped by the renderer and the output is sanitized
+// again, so a description can never inject scripts into a client.
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(
+		extension.TaskList,
+		extension.Strikethrough,
+		extension.Table,
+	))
+
+	markdownPolicy = func() *bluemonday.Policy {
+		p := bluemonday.UGCPolicy()
+		p.AllowURLSchemes("http", "https", "mailto")
+		p.RequireNoFollowOnLinks(true)
+		p.RequireNoReferrerOnLinks(true)
+		p.AddTargetBlankToFullyQualifiedLinks(true)
+		// Task list checkboxes, read-only
+		p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+		p.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^$`)).OnElements("input")
+		return p
+	}()
+)
+
+// renderMarkdown turns a description into sanitized HTML
+func renderMarkdown(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return markdownPolicy.Sanitize(buf.String()), nil
+}
+
+// markdownTaskSummary counts the task list checkboxes in a description
+func markdownTaskSummary(source string) models.ChecklistSummary {
+	var summary models.ChecklistSummary
+	if source == "" {
+		return summary
+	}
+	doc := markdown.Parser().Parse(text.NewReader([]byte(source)))
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if box, ok := n.(*extast.TaskCheckBox); ok && entering {
+			summary.Total++
+			if box.IsChecked {
+				summary.Done++
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	return summary
+}
+
+// setDescriptionTasks fills in the checkbox counts of the task descriptions
+func setDescriptionTasks(tasks ...*models.Task) {
+	for _, t := range tasks {
+		t.DescriptionTasks = markdownTaskSummary(t.Description)
+	}
+}
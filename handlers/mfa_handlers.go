@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/models"
+	"go_taskmanagement/ratelimit"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// mfaAttempts limits code guesses per user across login, confirmation and disabling
+var mfaAttempts = ratelimit.New(5, auth.MFAChallengeTTL)
+
+// MFAStatusResponse iki adımlı doğrulama durumu
+type MFAStatusResponse struct {
+	Enabled                bool       `json:"enabled" example:"true"`
+	EnabledAt              *time.Time `json:"enabled_at"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining" example:"10"`
+}
+
+// MFAEnrollResponse authenticator uygulamasına eklenecek gizli anahtar
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	OTPAuthURI string `json:"otpauth_uri" example:"otpauth://totp/Task%20Management:hakan@example.com?algorithm=SHA1&digits=6&issuer=Task+Management&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+// MFACodeRequest authenticator ya da kurtarma kodu isteği modeli
+type MFACodeRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+// MFADisableRequest iki adımlı doğrulamayı kapatma isteği modeli
+type MFADisableRequest struct {
+	Password string `json:"password" example:"secret123"`
+	Code     string `json:"code" example:"123456"` // Authenticator ya da kurtarma kodu
+}
+
+// RecoveryCodesResponse yalnızca bir kez gösterilen kurtarma kodları
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k7fq-2mxa"`
+}
+
+// MFAChallengeResponse iki adımlı doğrulaması açık kullanıcılar için giriş yanıtı
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required" example:"true"`
+	MFAToken    string `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresIn   int    `json:"expires_in" example:"300"` // Saniye
+}
+
+// LoginMFARequest girişin ikinci adımı isteği modeli
+type LoginMFARequest struct {
+	MFAToken string `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Code     string `json:"code" example:"123456"` // Authenticator ya da kurtarma kodu
+}
+
+// newMFAChallenge issues the token for the second login step
+func newMFAChallenge(userID uint) (*MFAChallengeResponse, error) {
+	jti, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	token, err := auth.NewMFAChallenge(userID, jti)
+	if err != nil {
+		return nil, err
+	}
+	return &MFAChallengeResponse{MFARequired: true, MFAToken: token, ExpiresIn: int(auth.MFAChallengeTTL.Seconds())}, nil
+}
+
+// mfaRateLimited answers 429 once the user has used up their code attempts
+func mfaRateLimited(c *fiber.Ctx, userID uint) (bool, error) {
+	if ok, retryAfter := mfaAttempts.Allow(strconv.FormatUint(uint64(userID), 10)); !ok {
+		return true, tooManyRequests(c, retryAfter)
+	}
+	return false, nil
+}
+
+// MFAStatusHandler iki adımlı doğrulama durumunu döner
+// @ID MFAStatusHandler
+// @Summary 2FA durumu
+// @Description İki adımlı doğrulamanın açık olup olmadığını ve kalan kurtarma kodu sayısını döner
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} MFAStatusResponse
+// @Failure 401 {object} map[string]string
+// @Router /2fa [get]
+func MFAStatusHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	db := taskDB()
+	user, err := findUserByID(db, userID)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	remaining, err := remainingRecoveryCodes(db, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "2FA durumu alınamadı"})
+	}
+	return c.JSON(MFAStatusResponse{Enabled: user.TOTPEnabledAt != nil, EnabledAt: user.TOTPEnabledAt, RecoveryCodesRemaining: remaining})
+}
+
+// MFAEnrollHandler iki adımlı doğrulama kaydını başlatır
+// @ID MFAEnrollHandler
+// @Summary 2FA kaydını başlat
+// @Description Yeni bir TOTP gizli anahtarı oluşturur ve authenticator uygulamasına eklenecek otpauth URI'sini döner. 2FA, POST /2fa/confirm ile ilk kod doğrulanana kadar kapalı kalır; tekrar çağrılırsa önceki bekleyen anahtar geçersiz olur.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} MFAEnrollResponse
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /2fa/enroll [post]
+func MFAEnrollHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	user, err := startTOTPEnrollment(taskDB(), userID)
+	switch {
+	case errors.Is(err, errUserNotFound):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	case errors.Is(err, errMFAEnabled):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "İki adımlı doğrulama zaten açık"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "2FA kaydı başlatılamadı"})
+	}
+	return c.JSON(MFAEnrollResponse{Secret: user.TOTPSecret, OTPAuthURI: auth.TOTPURI(user.TOTPSecret, user.Email)})
+}
+
+// MFAConfirmHandler ilk kod ile iki adımlı doğrulamayı açar
+// @ID MFAConfirmHandler
+// @Summary 2FA'yı onayla
+// @Description Authenticator uygulamasındaki ilk kodu doğrular, iki adımlı doğrulamayı açar ve 10 tek kullanımlık kurtarma kodu döner. Kurtarma kodları yalnızca bu yanıtta gösterilir.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body MFACodeRequest true "Authenticator kodu"
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Failure 429 {object} map[string]string
+// @Router /2fa/confirm [post]
+func MFAConfirmHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	var input MFACodeRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if input.Code == "" {
+		return validationFailed(c, fieldErrors{"code": "Kod zorunlu"})
+	}
+	if limited, err := mfaRateLimited(c, userID); limited {
+		return err
+	}
+
+	codes, err := confirmTOTP(taskDB(), userID, input.Code)
+	switch {
+	case errors.Is(err, errUserNotFound):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	case errors.Is(err, errMFAEnabled):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "İki adımlı doğrulama zaten açık"})
+	case errors.Is(err, errMFANoEnrollment):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Önce POST /2fa/enroll ile kayıt başlatın"})
+	case errors.Is(err, errMFACodeInvalid):
+		return validationFailed(c, fieldErrors{"code": "Kod geçersiz"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "2FA açılamadı"})
+	}
+	return c.JSON(RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// MFARecoveryCodesHandler yeni kurtarma kodları üretir
+// @ID MFARecoveryCodesHandler
+// @Summary Kurtarma kodlarını yenile
+// @Description Geçerli bir authenticator ya da kurtarma kodu ile eski kurtarma kodlarını iptal eder ve 10 yeni kod döner
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body MFACodeRequest true "Authenticator ya da kurtarma kodu"
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Failure 429 {object} map[string]string
+// @Router /2fa/recovery-codes [post]
+func MFARecoveryCodesHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	var input MFACodeRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if input.Code == "" {
+		return validationFailed(c, fieldErrors{"code": "Kod zorunlu"})
+	}
+	if limited, err := mfaRateLimited(c, userID); limited {
+		return err
+	}
+
+	db := taskDB()
+	user, err := findUserByID(db, userID)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	switch err := checkSecondFactor(db, user, input.Code); {
+	case errors.Is(err, errMFANotEnabled):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "İki adımlı doğrulama kapalı"})
+	case errors.Is(err, errMFACodeInvalid):
+		return validationFailed(c, fieldErrors{"code": "Kod geçersiz"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kod doğrulanamadı"})
+	}
+	codes, err := regenerateRecoveryCodes(db, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kurtarma kodları oluşturulamadı"})
+	}
+	return c.JSON(RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// MFADisableHandler iki adımlı doğrulamayı kapatır
+// @ID MFADisableHandler
+// @Summary 2FA'yı kapat
+// @Description Şifre ve geçerli bir authenticator ya da kurtarma kodu ile iki adımlı doğrulamayı kapatır; gizli anahtar ve kurtarma kodları silinir
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body MFADisableRequest true "Şifre ve kod"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Failure 429 {object} map[string]string
+// @Router /2fa/disable [post]
+func MFADisableHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	var input MFADisableRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	errs := fieldErrors{}
+	if input.Password == "" {
+		errs["password"] = "Şifre zorunlu"
+	}
+	if input.Code == "" {
+		errs["code"] = "Kod zorunlu"
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+	if limited, err := mfaRateLimited(c, userID); limited {
+		return err
+	}
+
+	db := taskDB()
+	user, err := findUserByID(db, userID)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)) != nil {
+		return validationFailed(c, fieldErrors{"password": "Şifre yanlış"})
+	}
+	switch err := checkSecondFactor(db, user, input.Code); {
+	case errors.Is(err, errMFANotEnabled):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "İki adımlı doğrulama kapalı"})
+	case errors.Is(err, errMFACodeInvalid):
+		return validationFailed(c, fieldErrors{"code": "Kod geçersiz"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kod doğrulanamadı"})
+	}
+	if err := disableTOTP(db, user); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "2FA kapatılamadı"})
+	}
+	return c.JSON(fiber.Map{"message": "İki adımlı doğrulama kapatıldı"})
+}
+
+// LoginMFAHandler girişin ikinci adımını tamamlar
+// @ID LoginMFAHandler
+// @Summary 2FA ile girişi tamamla
+// @Description POST /login'in döndüğü mfa_token ile authenticator kodunu ya da bir kurtarma kodunu doğrular ve access ve refresh token döner. mfa_token 5 dakika geçerlidir ve tek kullanımlıktır; kullanıcı başına 5 dakikada 5 deneme yapılabilir. Hatalı kodlar hatalı şifreler gibi hesap ve IP başına sayılır ve kilitlenmeye yol açar; hesabın sayacı ancak kod doğrulanınca sıfırlanır.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body LoginMFARequest true "MFA token'ı ve kod"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /login/mfa [post]
+func LoginMFAHandler(c *fiber.Ctx) error {
+	var input LoginMFARequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if input.MFAToken == "" || input.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "mfa_token ve kod zorunlu"})
+	}
+
+	claims, err := auth.VerifyMFAChallenge(input.MFAToken)
+	if errors.Is(err, auth.ErrTokenExpired) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Doğrulama süresi dolmuş, yeniden giriş yapın"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Geçersiz mfa_token"})
+	}
+	revoked, err := auth.Revocations.IsRevoked(claims.ID, claims.UserID, claims.IssuedAt.Time)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Token doğrulanamadı"})
+	}
+	if revoked {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Geçersiz mfa_token"})
+	}
+	if limited, err := mfaRateLimited(c, claims.UserID); limited {
+		return err
+	}
+
+	db := taskDB()
+	user, err := findUserByID(db, claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Geçersiz mfa_token"})
+	}
+	// Wrong codes count against the account and the IP like wrong passwords
+	attempt, throttled, err := beginLogin(c, db, user.Email)
+	if throttled {
+		return err
+	}
+	switch err := checkSecondFactor(db, user, input.Code); {
+	case errors.Is(err, errMFACodeInvalid), errors.Is(err, errMFANotEnabled):
+		attempt.failed(user)
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Doğrulama kodu geçersiz"})
+	case err != nil:
+		attempt.release()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kod doğrulanamadı"})
+	}
+
+	// The challenge is spent; a second login needs the password again
+	if err := auth.Revocations.RevokeToken(claims.ID, claims.UserID, claims.ExpiresAt.Time); err != nil {
+		attempt.release()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Token oluşturulamadı"})
+	}
+	attempt.succeeded()
+	return loginSucceeded(c, db, *user)
+}
+
+// loginSucceeded answers a completed login with a new token pair
+func loginSucceeded(c *fiber.Ctx, db *gorm.DB, user models.User) error {
+	deleteExpiredRefreshTokens(db, user.ID)
+	resp, err := issueTokens(db, user, "")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Token oluşturulamadı"})
+	}
+	return c.JSON(resp)
+}
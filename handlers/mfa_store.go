@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+const recoveryCodeCount = 10
+
+var (
+	errMFAEnabled      = errors.New("2fa already enabled")
+	errMFANotEnabled   = errors.New("2fa not enabled")
+	errMFANoEnrollment = errors.New("2fa enrollment not started")
+	errMFACodeInvalid  = errors.New("2fa code invalid")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCodes returns fresh codes like "k7fq-2mxa" and the rows storing their hashes
+func newRecoveryCodes(userID uint) ([]string, []models.RecoveryCode, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	rows := make([]models.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		rows = append(rows, models.RecoveryCode{UserID: userID, CodeHash: hashToken(code)})
+	}
+	return codes, rows, nil
+}
+
+// normalizeRecoveryCode accepts codes with or without the dash and in any case
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// startTOTPEnrollment gives the user a new pending secret. It replaces any earlier
+// pending one; 2FA stays off until confirmTOTP.
+func startTOTPEnrollment(db *gorm.DB, userID uint) (*models.User, error) {
+	user, err := findUserByID(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, errMFAEnabled
+	}
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		res := db.Model(&models.User{}).Where("id = ? AND totp_enabled_at IS NULL", userID).
+			Updates(map[string]interface{}{"totp_secret": secret, "totp_last_step": 0})
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil, errMFAEnabled
+		}
+	}
+
+	// In-memory mode updates the stored user through the pointer
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	return user, nil
+}
+
+// confirmTOTP turns 2FA on once the user proves the authenticator works, and returns the
+// recovery codes, which are never shown again
+func confirmTOTP(db *gorm.DB, userID uint, code string) ([]string, error) {
+	user, err := findUserByID(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, errMFAEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, errMFANoEnrollment
+	}
+	now := time.Now()
+	step, ok := auth.ValidateTOTP(user.TOTPSecret, code, now, user.TOTPLastStep)
+	if !ok {
+		return nil, errMFACodeInvalid
+	}
+	codes, rows, err := newRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if db != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			res := tx.Model(&models.User{}).Where("id = ? AND totp_enabled_at IS NULL", userID).
+				Updates(map[string]interface{}{"totp_enabled_at": now, "totp_last_step": step})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return errMFAEnabled
+			}
+			if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+				return err
+			}
+			return tx.Create(&rows).Error
+		})
+		if err != nil {
+			return nil, err
+		}
+		return codes, nil
+	}
+
+	// In-memory mode (fallback)
+	user.TOTPEnabledAt = &now
+	user.TOTPLastStep = step
+	replaceRecoveryCodes(userID, rows)
+	return codes, nil
+}
+
+// regenerateRecoveryCodes replaces the user's recovery codes with a new set
+func regenerateRecoveryCodes(db *gorm.DB, userID uint) ([]string, error) {
+	codes, rows, err := newRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+				return err
+			}
+			return tx.Create(&rows).Error
+		})
+		return codes, err
+	}
+
+	// In-memory mode (fallback)
+	replaceRecoveryCodes(userID, rows)
+	return codes, nil
+}
+
+func replaceRecoveryCodes(userID uint, rows []models.RecoveryCode) {
+	kept := models.RecoveryCodes[:0]
+	var maxID uint
+	for _, rc := range models.RecoveryCodes {
+		if rc.ID > maxID {
+			maxID = rc.ID
+		}
+		if rc.UserID != userID {
+			kept = append(kept, rc)
+		}
+	}
+	for i := range rows {
+		maxID++
+		rows[i].ID = maxID
+		rows[i].CreatedAt = time.Now()
+	}
+	models.RecoveryCodes = append(kept, rows...)
+}
+
+// checkSecondFactor accepts either a current authenticator code or an unused recovery
+// code. Both are consumed: the TOTP period can't be used again and the recovery code is
+// marked used.
+func checkSecondFactor(db *gorm.DB, user *models.User, code string) error {
+	if user.TOTPEnabledAt == nil {
+		return errMFANotEnabled
+	}
+	code = strings.TrimSpace(code)
+	if step, ok := auth.ValidateTOTP(user.TOTPSecret, code, time.Now(), user.TOTPLastStep); ok {
+		return useTOTPStep(db, user, step)
+	}
+	return useRecoveryCode(db, user.ID, normalizeRecoveryCode(code))
+}
+
+// useTOTPStep records the period of an accepted code. The conditional update makes two
+// concurrent logins with the same code race for it, and only one wins.
+func useTOTPStep(db *gorm.DB, user *models.User, step int64) error {
+	if db != nil {
+		res := db.Model(&models.User{}).Where("id = ? AND totp_last_step < ?", user.ID, step).Update("totp_last_step", step)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errMFACodeInvalid
+		}
+		return nil
+	}
+
+	// In-memory mode (fallback)
+	if user.TOTPLastStep >= step {
+		return errMFACodeInvalid
+	}
+	user.TOTPLastStep = step
+	return nil
+}
+
+func useRecoveryCode(db *gorm.DB, userID uint, code string) error {
+	if code == "" {
+		return errMFACodeInvalid
+	}
+	hash := hashToken(code)
+	now := time.Now()
+	if db != nil {
+		res := db.Model(&models.RecoveryCode{}).
+			Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+			Update("used_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errMFACodeInvalid
+		}
+		return nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.RecoveryCodes {
+		rc := &models.RecoveryCodes[i]
+		if rc.UserID == userID && rc.CodeHash == hash && rc.UsedAt == nil {
+			rc.UsedAt = &now
+			return nil
+		}
+	}
+	return errMFACodeInvalid
+}
+
+// disableTOTP turns 2FA off and drops the secret and recovery codes
+func disableTOTP(db *gorm.DB, user *models.User) error {
+	if db != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Model(&models.User{}).Where("id = ?", user.ID).
+				Updates(map[string]interface{}{"totp_secret": "", "totp_enabled_at": nil, "totp_last_step": 0}).Error
+			if err != nil {
+				return err
+			}
+			return tx.Where("user_id = ?", user.ID).Delete(&models.RecoveryCode{}).Error
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		replaceRecoveryCodes(user.ID, nil)
+	}
+	user.TOTPSecret = ""
+	user.TOTPEnabledAt = nil
+	user.TOTPLastStep = 0
+	return nil
+}
+
+// remainingRecoveryCodes counts the user's unused recovery codes
+func remainingRecoveryCodes(db *gorm.DB, userID uint) (int, error) {
+	if db != nil {
+		var count int64
+		err := db.Model(&models.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error
+		return int(count), err
+	}
+
+	// In-memory mode (fallback)
+	count := 0
+	for _, rc := range models.RecoveryCodes {
+		if rc.UserID == userID && rc.UsedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
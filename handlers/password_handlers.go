@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/lockout"
+	"go_taskmanagement/mail"
+	"go_taskmanagement/ratelimit"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minPasswordLength = 8
+	maxPasswordBytes  = 72 // bcrypt ignores anything longer and refuses to hash it
+)
+
+var (
+	forgotPasswordPerIP    = ratelimit.New(10, time.Hour)
+	forgotPasswordPerEmail = ratelimit.New(3, time.Hour)
+)
+
+// ForgotPasswordRequest şifre sıfırlama e-postası isteği modeli
+type ForgotPasswordRequest struct {
+	Email string `json:"email" example:"hakan@example.com"`
+}
+
+// ResetPasswordRequest yeni şifre belirleme isteği modeli
+type ResetPasswordRequest struct {
+	Token    string `json:"token" example:"Zm9yZ290LXBhc3N3b3JkLXRva2Vu"`
+	Password string `json:"password" example:"yeniSifre123"`
+}
+
+// appURL builds a link to the application from APP_BASE_URL (default http://localhost:8080)
+func appURL(path string, query url.Values) string {
+	base := strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")
+	if base == "" {
+		base = "http://localhost:8080"
+	}
+	return base + path + "?" + query.Encode()
+}
+
+// sendMail delivers the message in the background, so the response time does not
+// reveal whether an email was sent
+func sendMail(msg mail.Message) {
+	m := mail.Default()
+	go func() {
+		if err := m.Send(msg); err != nil {
+			log.Printf("Failed to send mail to %s: %v", msg.To, err)
+		}
+	}()
+}
+
+// tooManyRequests answers a rate-limited request with Retry-After
+func tooManyRequests(c *fiber.Ctx, retryAfter time.Duration) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "Çok fazla istek, lütfen daha sonra tekrar deneyin"})
+}
+
+// ForgotPasswordHandler şifre sıfırlama kodu gönderir
+// @ID ForgotPasswordHandler
+// @Summary Şifremi unuttum
+// @Description Email kayıtlıysa bir saat geçerli, tek kullanımlık bir şifre sıfırlama kodu gönderir; kod yeni şifreyle birlikte POST /password/reset isteğine token olarak verilir. Email'in kayıtlı olup olmadığı belli olmasın diye yanıt her zaman 202'dir. IP başına saatte 10 istek kabul edilir; aynı adrese saatte en fazla 3 e-posta gönderilir.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordRequest true "Hesabın e-posta adresi"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Failure 429 {object} map[string]string
+// @Router /password/forgot [post]
+func ForgotPasswordHandler(c *fiber.Ctx) error {
+	var input ForgotPasswordRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	email := strings.TrimSpace(input.Email)
+	if email == "" {
+		return validationFailed(c, fieldErrors{"email": "Email zorunlu"})
+	}
+	if ok, retryAfter := forgotPasswordPerIP.Allow(c.IP()); !ok {
+		return tooManyRequests(c, retryAfter)
+	}
+
+	accepted := func() error {
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"message": "Email kayıtlıysa şifre sıfırlama kodu gönderildi"})
+	}
+	// Over the per-address limit the request is accepted but nothing is sent
+	if ok, _ := forgotPasswordPerEmail.Allow(strings.ToLower(email)); !ok {
+		return accepted()
+	}
+
+	db := taskDB()
+	user, err := findUserByEmail(db, email)
+	if err != nil {
+		return accepted()
+	}
+	token, err := createPasswordReset(db, user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Şifre sıfırlama başlatılamadı"})
+	}
+	sendMail(mail.Message{
+		To:      user.Email,
+		Subject: "Şifre sıfırlama",
+		Body: "Merhaba " + user.Username + ",\n\n" +
+			"Şifrenizi sıfırlamak için aşağıdaki kodu yeni şifrenizle birlikte POST /password/reset isteğinde token olarak gönderin. Kod bir saat geçerlidir ve yalnızca bir kez kullanılabilir.\n\n" +
+			"Sıfırlama kodu: " + token + "\n\n" +
+			"Bu isteği siz yapmadıysanız bu e-postayı yok sayabilirsiniz.\n",
+	})
+	return accepted()
+}
+
+// ResetPasswordHandler sıfırlama token'ı ile yeni şifre belirler
+// @ID ResetPasswordHandler
+// @Summary Şifreyi sıfırla
+// @Description E-postadaki token ile yeni şifre belirler. Token tek kullanımlıktır. Başarılı sıfırlamadan sonra kullanıcının tüm oturumları kapatılır ve hesabın giriş kilidi kaldırılır.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordRequest true "Sıfırlama token'ı ve yeni şifre"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /password/reset [post]
+func ResetPasswordHandler(c *fiber.Ctx) error {
+	var input ResetPasswordRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if input.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Token zorunlu"})
+	}
+	if len([]rune(input.Password)) < minPasswordLength {
+		return validationFailed(c, fieldErrors{"password": "Şifre en az " + strconv.Itoa(minPasswordLength) + " karakter olmalı"})
+	}
+	if len(input.Password) > maxPasswordBytes {
+		return validationFailed(c, fieldErrors{"password": "Şifre en fazla " + strconv.Itoa(maxPasswordBytes) + " bayt olabilir"})
+	}
+
+	db := taskDB()
+	user, err := resetPassword(db, input.Token, func() (string, error) {
+		hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+		return string(hash), err
+	})
+	if errors.Is(err, errResetTokenInvalid) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veya süresi dolmuş token"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Şifre güncellenemedi"})
+	}
+
+	// Whoever knew the old password is logged out
+	if err := revokeUserRefreshTokens(db, user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Oturumlar kapatılamadı"})
+	}
+	if err := auth.Revocations.RevokeUser(user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Oturumlar kapatılamadı"})
+	}
+	// The owner proved control of the address, so the failures against the old password are forgotten
+	if err := loginAttemptStore(db).Reset(lockout.Key(lockout.KindAccount, user.Email)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Hesap kilidi kaldırılamadı"})
+	}
+	return c.JSON(fiber.Map{"message": "Şifre güncellendi, lütfen yeniden giriş yapın"})
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+const passwordResetTTL = time.Hour
+
+var errResetTokenInvalid = errors.New("password reset token invalid")
+
+// findUserByEmail loads a user by email from the database or the in-memory store
+func findUserByEmail(db *gorm.DB, email string) (*models.User, error) {
+	if db != nil {
+		var user models.User
+		if err := db.Where("email = ?", email).First(&user).Error; err != nil {
+			return nil, errUserNotFound
+		}
+		return &user, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.Users {
+		if models.Users[i].Email == email {
+			return &models.Users[i], nil
+		}
+	}
+	return nil, errUserNotFound
+}
+
+// createPasswordReset issues a reset token for the user and returns its raw value.
+// Earlier unused tokens of the user stop working, only the latest email counts.
+func createPasswordReset(db *gorm.DB, userID uint) (string, error) {
+	raw, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	reset := models.PasswordReset{
+		UserID:    userID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: now.Add(passwordResetTTL),
+	}
+	if db != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("user_id = ? AND used_at IS NULL", userID).Delete(&models.PasswordReset{}).Error; err != nil {
+				return err
+			}
+			return tx.Create(&reset).Error
+		})
+		return raw, err
+	}
+
+	// In-memory mode (fallback)
+	kept := models.PasswordResets[:0]
+	var max uint
+	for _, r := range models.PasswordResets {
+		if r.ID > max {
+			max = r.ID
+		}
+		if r.UserID != userID || r.UsedAt != nil {
+			kept = append(kept, r)
+		}
+	}
+	reset.ID = max + 1
+	reset.CreatedAt = now
+	models.PasswordResets = append(kept, reset)
+	return raw, nil
+}
+
+// resetPassword consumes a reset token and stores the new password hash.
+// hashPassword only runs once the token is consumed, so invalid tokens never
+// cost a bcrypt round; if it fails the token stays usable. It returns the user
+// whose password changed.
+func resetPassword(db *gorm.DB, raw string, hashPassword func() (string, error)) (*models.User, error) {
+	hash := hashToken(raw)
+	now := time.Now()
+	if db != nil {
+		var user models.User
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var reset models.PasswordReset
+			if err := tx.Where("token_hash = ?", hash).First(&reset).Error; err != nil {
+				return errResetTokenInvalid
+			}
+			// The conditional update makes the token single-use under concurrency
+			res := tx.Model(&models.PasswordReset{}).
+				Where("id = ? AND used_at IS NULL AND expires_at > ?", reset.ID, now).
+				Update("used_at", now)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return errResetTokenInvalid
+			}
+			if err := tx.First(&user, reset.UserID).Error; err != nil {
+				return errResetTokenInvalid
+			}
+			passwordHash, err := hashPassword()
+			if err != nil {
+				return err
+			}
+			user.Password = passwordHash
+			return tx.Model(&user).Update("password", passwordHash).Error
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &user, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.PasswordResets {
+		reset := &models.PasswordResets[i]
+		if reset.TokenHash != hash {
+			continue
+		}
+		if reset.UsedAt != nil || !now.Before(reset.ExpiresAt) {
+			return nil, errResetTokenInvalid
+		}
+		user, err := findUserByID(nil, reset.UserID)
+		if err != nil {
+			return nil, errResetTokenInvalid
+		}
+		reset.UsedAt = &now
+		passwordHash, err := hashPassword()
+		if err != nil {
+			reset.UsedAt = nil
+			return nil, err
+		}
+		user.Password = passwordHash
+		return user, nil
+	}
+	return nil, errResetTokenInvalid
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go_taskmanagement/models"
+	"go_taskmanagement/workflow"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ProjectCreateRequest proje oluşturma isteği modeli
+type ProjectCreateRequest struct {
+	Name string `json:"name" example:"Web sitesi"`
+	// Gönderilmezse varsayılan iş akışı kopyalanır
+	Workflow *workflow.Workflow `json:"workflow,omitempty"`
+}
+
+// ProjectWorkflowRequest proje iş akışını değiştirme isteği modeli
+type ProjectWorkflowRequest struct {
+	Workflow workflow.Workflow `json:"workflow"`
+	// Kaldırılan durumdaki görevlerin taşınacağı yeni durumlar (eski durum -> yeni durum)
+	StatusMap map[string]string `json:"status_map,omitempty" example:"review:in_progress"`
+}
+
+// ProjectWorkflowMigration iş akışı değişikliğinin sonucu
+type ProjectWorkflowMigration struct {
+	Project models.Project `json:"project"`
+	// Eski durum başına taşınan (dry_run ise taşınacak) görev sayısı
+	Migrated map[string]int `json:"migrated"`
+	DryRun   bool           `json:"dry_run"`
+}
+
+// ProjectsListHandler kullanıcının projelerini listeler
+// @ID ProjectsListHandler
+// @Summary Projeleri listele
+// @Description Giriş yapan kullanıcının projelerini iş akışlarıyla birlikte döner
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Project
+// @Router /projects [get]
+func ProjectsListHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	projects := []models.Project{}
+	if db := taskDB(); db != nil {
+		db.Where("user_id = ?", userID).Order("id").Find(&projects)
+	} else {
+		// In-memory mode (fallback)
+		for _, p := range models.Projects {
+			if p.UserID == userID {
+				projects = append(projects, p)
+			}
+		}
+	}
+	return c.JSON(projects)
+}
+
+// ProjectCreateHandler yeni proje oluşturur
+// @ID ProjectCreateHandler
+// @Summary Proje oluştur
+// @Description Kendi iş akışına sahip yeni bir proje oluşturur. İş akışı gönderilmezse varsayılan iş akışı kullanılır.
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param project body ProjectCreateRequest true "Proje"
+// @Success 201 {object} models.Project
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /projects [post]
+func ProjectCreateHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	var input ProjectCreateRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Proje adı zorunlu"})
+	}
+
+	wf := workflow.Default().Clone()
+	if input.Workflow != nil {
+		if err := input.Workflow.Validate(); err != nil {
+			return validationFailed(c, fieldErrors{"workflow": err.Error()})
+		}
+		wf = *input.Workflow
+	}
+
+	project := models.Project{UserID: userID, Name: input.Name, Workflow: wf}
+	if err := createProject(taskDB(), &project); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Proje oluşturulamadı"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(project)
+}
+
+// ProjectDetailHandler proje detayını döner
+// @ID ProjectDetailHandler
+// @Summary Proje detayını görüntüle
+// @Description Projeyi iş akışıyla birlikte döner
+// @Tags Projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Proje ID"
+// @Success 200 {object} models.Project
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id} [get]
+func ProjectDetailHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz proje ID"})
+	}
+
+	project, err := findUserProject(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Proje bulunamadı veya yetkiniz yok"})
+	}
+	return c.JSON(project)
+}
+
+// ProjectWorkflowUpdateHandler projenin iş akışını değiştirir
+// @ID ProjectWorkflowUpdateHandler
+// @Summary Proje iş akışını değiştir
+// @Description Projenin iş akışını değiştirir. Kaldırılan bir durumda görev varsa status_map ile taşınacağı durum belirtilmelidir; görevler aynı transaction içinde taşınır. dry_run=true ile hiçbir şey değiştirmeden kaç görevin taşınacağı görülür.
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Proje ID"
+// @Param dry_run query bool false "Sadece önizleme"
+// @Param request body ProjectWorkflowRequest true "Yeni iş akışı"
+// @Success 200 {object} ProjectWorkflowMigration
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /projects/{id}/workflow [put]
+func ProjectWorkflowUpdateHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz proje ID"})
+	}
+
+	var input ProjectWorkflowRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if err := input.Workflow.Validate(); err != nil {
+		return validationFailed(c, fieldErrors{"workflow": err.Error()})
+	}
+
+	project, err := findUserProject(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Proje bulunamadı veya yetkiniz yok"})
+	}
+
+	removed := project.Workflow.Removed(&input.Workflow)
+	counts, err := countProjectTasks(taskDB(), project.ID, removed)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görevler alınamadı"})
+	}
+	if errs := validateStatusMap(removed, counts, &input.Workflow, input.StatusMap); len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	// Only statuses that still have tasks need to be migrated
+	statusMap := make(map[string]string)
+	for from, to := range input.StatusMap {
+		if counts[from] > 0 {
+			statusMap[from] = to
+		}
+	}
+
+	if c.QueryBool("dry_run") {
+		migrated := make(map[string]int, len(statusMap))
+		for from := range statusMap {
+			migrated[from] = counts[from]
+		}
+		return c.JSON(ProjectWorkflowMigration{Project: *project, Migrated: migrated, DryRun: true})
+	}
+
+	migrated, err := replaceProjectWorkflow(taskDB(), userID, project, input.Workflow, statusMap)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "İş akışı güncellenemedi"})
+	}
+	return c.JSON(ProjectWorkflowMigration{Project: *project, Migrated: migrated})
+}
+
+// validateStatusMap checks that every removed status that still has tasks is
+// mapped to a status of the new workflow, and that nothing else is mapped
+func validateStatusMap(removed []string, counts map[string]int, next *workflow.Workflow, statusMap map[string]string) fieldErrors {
+	errs := fieldErrors{}
+	for from, to := range statusMap {
+		switch {
+		case !slices.Contains(removed, from):
+			errs["status_map."+from] = "Yalnızca kaldırılan durumlar taşınabilir"
+		case !next.HasStatus(to):
+			errs["status_map."+from] = fmt.Sprintf("%s yeni iş akışında bulunmuyor", to)
+		}
+	}
+	for _, status := range removed {
+		if _, mapped := statusMap[status]; !mapped && counts[status] > 0 {
+			errs["status_map."+status] = fmt.Sprintf("Bu durumda %d görev var, taşınacağı durum belirtilmeli", counts[status])
+		}
+	}
+	return errs
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"go_taskmanagement/models"
+	"go_taskmanagement/workflow"
+
+	"gorm.io/gorm"
+)
+
+var errProjectNotFound = errors.New("project not found")
+
+// findUserProject loads a project owned by the user
+func findUserProject(db *gorm.DB, userID, id uint) (*models.Project, error) {
+	if db != nil {
+		var project models.Project
+		if err := db.Where("id = ? AND user_id = ?", id, userID).First(&project).Error; err != nil {
+			return nil, err
+		}
+		return &project, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.Projects {
+		if models.Projects[i].ID == id && models.Projects[i].UserID == userID {
+			return &models.Projects[i], nil
+		}
+	}
+	return nil, errProjectNotFound
+}
+
+// findProject loads a project regardless of its owner
+func findProject(db *gorm.DB, id uint) (*models.Project, error) {
+	if db != nil {
+		var project models.Project
+		if err := db.First(&project, id).Error; err != nil {
+			return nil, err
+		}
+		return &project, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.Projects {
+		if models.Projects[i].ID == id {
+			return &models.Projects[i], nil
+		}
+	}
+	return nil, errProjectNotFound
+}
+
+// createProject stores a new project
+func createProject(db *gorm.DB, project *models.Project) error {
+	if db != nil {
+		return db.Create(project).Error
+	}
+
+	// In-memory mode (fallback)
+	now := time.Now()
+	project.ID = uint(len(models.Projects) + 1)
+	project.CreatedAt = now
+	project.UpdatedAt = now
+	models.Projects = append(models.Projects, *project)
+	return nil
+}
+
+// countProjectTasks counts the project's tasks in each of the given statuses.
+// Trashed tasks are counted too so that restoring them never yields an unknown status.
+func countProjectTasks(db *gorm.DB, projectID uint, statuses []string) (map[string]int, error) {
+	counts := make(map[string]int, len(statuses))
+	if len(statuses) == 0 {
+		return counts, nil
+	}
+
+	if db != nil {
+		var rows []struct {
+			Status string
+			Count  int
+		}
+		err := db.Unscoped().Model(&models.Task{}).
+			Select("status, count(*) AS count").
+			Where("project_id = ? AND status IN ?", projectID, statuses).
+			Group("status").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			counts[r.Status] = r.Count
+		}
+		return counts, nil
+	}
+
+	// In-memory mode (fallback)
+	wanted := make(map[string]bool, len(statuses))
+	for _, s := range statuses {
+		wanted[s] = true
+	}
+	for _, t := range models.Tasks {
+		if t.ProjectID != nil && *t.ProjectID == projectID && wanted[t.Status] {
+			counts[t.Status]++
+		}
+	}
+	return counts, nil
+}
+
+// replaceProjectWorkflow swaps the project's workflow and moves tasks out of
+// removed statuses according to statusMap, all in one transaction. Every moved
+// task lands at the end of its new column, keeping its old order, and gets a
+// new version and a status entry in its history.
+func replaceProjectWorkflow(db *gorm.DB, actorID uint, project *models.Project, next workflow.Workflow, statusMap map[string]string) (map[string]int, error) {
+	migrated := make(map[string]int, len(statusMap))
+	from := make([]string, 0, len(statusMap))
+	for status := range statusMap {
+		from = append(from, status)
+	}
+
+	if db != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// Updating through the struct lets gorm apply the json serializer
+			project.Workflow = next
+			if err := tx.Model(project).Select("workflow", "updated_at").Updates(project).Error; err != nil {
+				return err
+			}
+			// Load every task to migrate first, so one moved into a status that is itself
+			// being migrated isn't moved twice
+			var tasks []models.Task
+			if err := tx.Unscoped().Where("project_id = ? AND status IN ?", project.ID, from).
+				Order("rank").Order("id").Find(&tasks).Error; err != nil {
+				return err
+			}
+			now := time.Now()
+			for i := range tasks {
+				t := &tasks[i]
+				to := statusMap[t.Status]
+				// Migrated tasks go to the end of their new column, in their old order
+				r, err := rankInColumn(tx, t, to, nil, nil)
+				if err != nil {
+					return err
+				}
+				err = tx.Unscoped().Model(&models.Task{}).Where("id = ?", t.ID).Updates(map[string]interface{}{
+					"status":     to,
+					"rank":       r,
+					"version":    gorm.Expr("version + 1"),
+					"updated_at": now,
+				}).Error
+				if err != nil {
+					return err
+				}
+				if err := recordTaskActivity(tx, t.ID, actorID, models.ActivityUpdated, "status", t.Status, to); err != nil {
+					return err
+				}
+				migrated[t.Status]++
+			}
+			return nil
+		})
+		return migrated, err
+	}
+
+	// In-memory mode (fallback)
+	now := time.Now()
+	var tasks []*models.Task
+	for i := range models.Tasks {
+		t := &models.Tasks[i]
+		if t.ProjectID == nil || *t.ProjectID != project.ID {
+			continue
+		}
+		if _, ok := statusMap[t.Status]; ok {
+			tasks = append(tasks, t)
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Rank != tasks[j].Rank {
+			return tasks[i].Rank < tasks[j].Rank
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	for _, t := range tasks {
+		to := statusMap[t.Status]
+		r, err := rankInColumn(nil, t, to, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := recordTaskActivity(nil, t.ID, actorID, models.ActivityUpdated, "status", t.Status, to); err != nil {
+			return nil, err
+		}
+		migrated[t.Status]++
+		t.Status = to
+		t.Rank = r
+		t.Version++
+		t.UpdatedAt = now
+	}
+	project.Workflow = next
+	project.UpdatedAt = now
+	return migrated, nil
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TaskMoveRequest görevi pano üzerinde taşıma isteği modeli
+type TaskMoveRequest struct {
+	Status   string `json:"status,omitempty" example:"in_progress"` // Hedef sütun; verilmezse mevcut durum
+	AfterID  *uint  `json:"after_id,omitempty" example:"4"`         // Bu görevin hemen arkasına yerleştir
+	BeforeID *uint  `json:"before_id,omitempty" example:"5"`        // Bu görevin hemen önüne yerleştir
+}
+
+// TaskMoveHandler görevin durumunu ve sütundaki yerini birlikte değiştirir
+// @ID TaskMoveHandler
+// @Summary Görevi panoda taşı
+// @Description Görevi bir durum sütununa taşır ve sütunda after_id görevinin arkasına ya da before_id görevinin önüne yerleştirir; ikisi de verilmezse sütunun sonuna koyar. Durum ve sıra tek transaction içinde değişir, yalnızca taşınan görev yazılır. Durum değişikliği iş akışı kurallarına uyar.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param If-Match header string false "Görevin beklenen ETag değeri"
+// @Param force query bool false "Açık engelleyen görevler olsa da tamamla"
+// @Param request body TaskMoveRequest true "Hedef sütun ve konum"
+// @Success 200 {object} models.Task
+// @Header 200 {string} ETag "Görevin güncel sürümü"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /tasks/{id}/move [post]
+func TaskMoveHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	idStr := c.Params("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	var input TaskMoveRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if input.AfterID != nil && input.BeforeID != nil {
+		return validationFailed(c, fieldErrors{"before_id": "after_id ve before_id birlikte kullanılamaz"})
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	if !ifMatchSatisfied(c, task) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
+	}
+
+	if input.Status == "" {
+		input.Status = task.Status
+	}
+	updates := map[string]interface{}{"status": input.Status}
+	if errs := validateTaskUpdate(task, updates); len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+	if errs := validateTaskBlockers(taskDB(), task, updates, c.QueryBool("force")); len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	if err := moveTask(taskDB(), userID, task, input.Status, input.AfterID, input.BeforeID); err != nil {
+		switch {
+		case errors.Is(err, errRankAnchorNotFound):
+			field := "after_id"
+			if input.BeforeID != nil {
+				field = "before_id"
+			}
+			return validationFailed(c, fieldErrors{field: "Hedef sütunda böyle bir görev yok"})
+		case errors.Is(err, errTaskVersionConflict):
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev taşınamadı"})
+	}
+
+	setComputedFields(taskDB(), task)
+	c.Set(fiber.HeaderETag, taskETag(task))
+	return c.JSON(task)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+
+	"go_taskmanagement/models"
+	"go_taskmanagement/rank"
+
+	"gorm.io/gorm"
+)
+
+var errRankAnchorNotFound = errors.New("anchor task is not in the target column")
+
+// A status column is the set of a user's live tasks with the same project and status.
+// Tasks are ordered in a column by rank, then id.
+
+// columnQuery selects the other tasks of the column the task would be in with the given status
+func columnQuery(db *gorm.DB, task *models.Task, status string) *gorm.DB {
+	query := db.Model(&models.Task{}).Where("user_id = ? AND status = ? AND id <> ?", task.UserID, status, task.ID)
+	if task.ProjectID != nil {
+		return query.Where("project_id = ?", *task.ProjectID)
+	}
+	return query.Where("project_id IS NULL")
+}
+
+// inColumn reports whether other is in the column the task would be in with the given status
+func inColumn(task *models.Task, status string, other models.Task) bool {
+	if other.ID == task.ID || other.UserID != task.UserID || other.Status != status || other.DeletedAt.Valid {
+		return false
+	}
+	if task.ProjectID == nil || other.ProjectID == nil {
+		return task.ProjectID == nil && other.ProjectID == nil
+	}
+	return *task.ProjectID == *other.ProjectID
+}
+
+// rankInColumn returns a rank that places the task right after afterID, right before
+// beforeID, or at the end of the column when neither is given. With a database it
+// takes a per-user lock, so db should be a transaction.
+func rankInColumn(db *gorm.DB, task *models.Task, status string, afterID, beforeID *uint) (string, error) {
+	var prev, next string
+
+	if db != nil {
+		if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext('task_rank'), ?)", int32(task.UserID)).Error; err != nil {
+			return "", err
+		}
+		// nearest returns the first rank of the column in the given order, past bound if set
+		nearest := func(order, op, bound string) (string, error) {
+			var ranks []string
+			query := columnQuery(db, task, status)
+			if op != "" {
+				query = query.Where("rank "+op+" ?", bound)
+			}
+			err := query.Order("rank "+order).Limit(1).Pluck("rank", &ranks).Error
+			if err != nil || len(ranks) == 0 {
+				return "", err
+			}
+			return ranks[0], nil
+		}
+		anchor := func(id uint) (string, error) {
+			var ranks []string
+			err := columnQuery(db, task, status).Where("id = ?", id).Pluck("rank", &ranks).Error
+			if err != nil {
+				return "", err
+			}
+			if len(ranks) == 0 {
+				return "", errRankAnchorNotFound
+			}
+			return ranks[0], nil
+		}
+
+		var err error
+		switch {
+		case afterID != nil:
+			if prev, err = anchor(*afterID); err == nil {
+				next, err = nearest("ASC", ">", prev)
+			}
+		case beforeID != nil:
+			if next, err = anchor(*beforeID); err == nil {
+				prev, err = nearest("DESC", "<", next)
+			}
+		default:
+			prev, err = nearest("DESC", "", "")
+		}
+		if err != nil {
+			return "", err
+		}
+		return rank.Between(prev, next), nil
+	}
+
+	// In-memory mode (fallback)
+	var anchor *models.Task
+	for i := range models.Tasks {
+		t := models.Tasks[i]
+		if inColumn(task, status, t) && ((afterID != nil && t.ID == *afterID) || (beforeID != nil && t.ID == *beforeID)) {
+			anchor = &models.Tasks[i]
+		}
+	}
+	if (afterID != nil || beforeID != nil) && anchor == nil {
+		return "", errRankAnchorNotFound
+	}
+	switch {
+	case afterID != nil:
+		prev = anchor.Rank
+	case beforeID != nil:
+		next = anchor.Rank
+	}
+	for _, t := range models.Tasks {
+		if !inColumn(task, status, t) {
+			continue
+		}
+		switch {
+		case afterID != nil:
+			if t.Rank > prev && (next == "" || t.Rank < next) {
+				next = t.Rank
+			}
+		case beforeID != nil:
+			if t.Rank < next && t.Rank > prev {
+				prev = t.Rank
+			}
+		default:
+			if t.Rank > prev {
+				prev = t.Rank
+			}
+		}
+	}
+	return rank.Between(prev, next), nil
+}
+
+// placeOnStatusChange moves the task to the end of its new column when an update
+// changes its status without saying where it should go
+func placeOnStatusChange(db *gorm.DB, task *models.Task, updates map[string]interface{}) error {
+	status, ok := updates["status"].(string)
+	if !ok || status == task.Status {
+		return nil
+	}
+	if _, ok := updates["rank"]; ok {
+		return nil
+	}
+	r, err := rankInColumn(db, task, status, nil, nil)
+	if err != nil {
+		return err
+	}
+	updates["rank"] = r
+	return nil
+}
+
+// moveTask changes the status and the position of the task in one transaction
+func moveTask(db *gorm.DB, actorID uint, task *models.Task, status string, afterID, beforeID *uint) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			r, err := rankInColumn(tx, task, status, afterID, beforeID)
+			if err != nil {
+				return err
+			}
+			return saveTaskUpdates(tx, actorID, task, map[string]interface{}{"status": status, "rank": r})
+		})
+	}
+
+	// In-memory mode (fallback)
+	r, err := rankInColumn(nil, task, status, afterID, beforeID)
+	if err != nil {
+		return err
+	}
+	return saveTaskUpdates(nil, actorID, task, map[string]interface{}{"status": status, "rank": r})
+}
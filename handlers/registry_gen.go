@@ -5,14 +5,73 @@ import "github.com/gofiber/fiber/v2"
 
 func init() {
 	OperationRegistry = map[string]fiber.Handler{
-		"RegisterHandler":    RegisterHandler,
-		"TaskCreateHandler":  TaskCreateHandler,
-		"PublicTasksHandler": PublicTasksHandler,
-		"TaskDetailHandler":  TaskDetailHandler,
-		"TaskUpdateHandler":  TaskUpdateHandler,
-		"LoginHandler":       LoginHandler,
-		"LogoutHandler":      LogoutHandler,
-		"TasksListHandler":   TasksListHandler,
-		"TaskDeleteHandler":  TaskDeleteHandler,
+		"RegisterHandler":              RegisterHandler,
+		"TaskCreateHandler":            TaskCreateHandler,
+		"PublicTasksHandler":           PublicTasksHandler,
+		"TaskDetailHandler":            TaskDetailHandler,
+		"TaskUpdateHandler":            TaskUpdateHandler,
+		"LoginHandler":                 LoginHandler,
+		"LogoutHandler":                LogoutHandler,
+		"TasksListHandler":             TasksListHandler,
+		"TaskDeleteHandler":            TaskDeleteHandler,
+		"ProjectDetailHandler":         ProjectDetailHandler,
+		"ChecklistItemCreateHandler":   ChecklistItemCreateHandler,
+		"AdminRoleDeleteHandler":       AdminRoleDeleteHandler,
+		"ChecklistItemDeleteHandler":   ChecklistItemDeleteHandler,
+		"TrashListHandler":             TrashListHandler,
+		"MFAStatusHandler":             MFAStatusHandler,
+		"AdminRoleCreateHandler":       AdminRoleCreateHandler,
+		"APITokenDeleteHandler":        APITokenDeleteHandler,
+		"LogoutAllHandler":             LogoutAllHandler,
+		"AdminUsersListHandler":        AdminUsersListHandler,
+		"TaskChecklistHandler":         TaskChecklistHandler,
+		"TaskTimeEntriesHandler":       TaskTimeEntriesHandler,
+		"TimerStartHandler":            TimerStartHandler,
+		"LoginMFAHandler":              LoginMFAHandler,
+		"TimeEntryDeleteHandler":       TimeEntryDeleteHandler,
+		"TaskDependencyAddHandler":     TaskDependencyAddHandler,
+		"TaskDependencyRemoveHandler":  TaskDependencyRemoveHandler,
+		"APITokenCreateHandler":        APITokenCreateHandler,
+		"ProjectCreateHandler":         ProjectCreateHandler,
+		"TimeEntryCreateHandler":       TimeEntryCreateHandler,
+		"TaskBulkHandler":              TaskBulkHandler,
+		"RefreshTokenHandler":          RefreshTokenHandler,
+		"ChecklistItemMoveHandler":     ChecklistItemMoveHandler,
+		"TimeReportHandler":            TimeReportHandler,
+		"ResetPasswordHandler":         ResetPasswordHandler,
+		"TemplateDeleteHandler":        TemplateDeleteHandler,
+		"TaskPatchHandler":             TaskPatchHandler,
+		"TaskRestoreHandler":           TaskRestoreHandler,
+		"AdminLockoutDeleteHandler":    AdminLockoutDeleteHandler,
+		"TemplateDetailHandler":        TemplateDetailHandler,
+		"AdminRolesListHandler":        AdminRolesListHandler,
+		"MFAConfirmHandler":            MFAConfirmHandler,
+		"CustomFieldCreateHandler":     CustomFieldCreateHandler,
+		"TemplateCreateHandler":        TemplateCreateHandler,
+		"TaskMoveHandler":              TaskMoveHandler,
+		"ChecklistItemUpdateHandler":   ChecklistItemUpdateHandler,
+		"TimerHandler":                 TimerHandler,
+		"TemplatesListHandler":         TemplatesListHandler,
+		"BurndownHandler":              BurndownHandler,
+		"TaskReopenHandler":            TaskReopenHandler,
+		"TaskActivityHandler":          TaskActivityHandler,
+		"AdminUserRoleHandler":         AdminUserRoleHandler,
+		"MFADisableHandler":            MFADisableHandler,
+		"JWKSHandler":                  JWKSHandler,
+		"MFARecoveryCodesHandler":      MFARecoveryCodesHandler,
+		"ResendVerificationHandler":    ResendVerificationHandler,
+		"ForgotPasswordHandler":        ForgotPasswordHandler,
+		"ProjectWorkflowUpdateHandler": ProjectWorkflowUpdateHandler,
+		"TimerStopHandler":             TimerStopHandler,
+		"MFAEnrollHandler":             MFAEnrollHandler,
+		"APITokensListHandler":         APITokensListHandler,
+		"ProjectsListHandler":          ProjectsListHandler,
+		"CustomFieldsListHandler":      CustomFieldsListHandler,
+		"TaskDependenciesHandler":      TaskDependenciesHandler,
+		"AdminPublicTaskDeleteHandler": AdminPublicTaskDeleteHandler,
+		"CustomFieldDeleteHandler":     CustomFieldDeleteHandler,
+		"VerifyEmailHandler":           VerifyEmailHandler,
+		"TemplateInstantiateHandler":   TemplateInstantiateHandler,
+		"AdminLockoutsListHandler":     AdminLockoutsListHandler,
 	}
 }
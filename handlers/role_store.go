@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	errRoleNotFound   = errors.New("role not found")
+	errRoleExists     = errors.New("role already exists")
+	errRoleInUse      = errors.New("role is assigned to users")
+	errLastAdmin      = errors.New("last admin")
+	errRoleNotAllowed = errors.New("role grants permissions the caller lacks")
+	errPublicNotFound = errors.New("public task not found")
+)
+
+// RoleResponse bir rolü ve izinlerini tanımlar
+type RoleResponse struct {
+	Name        string   `json:"name" example:"moderator"`
+	Permissions []string `json:"permissions" example:"tasks:moderate"`
+	BuiltIn     bool     `json:"built_in" example:"false"` // user ve admin rolleri değiştirilemez
+}
+
+// isAdminEmail reports whether the address is listed in ADMIN_EMAILS (comma separated).
+// Such users become admins once they verify the address, which is how the first admin
+// is created; registering with the address alone grants nothing.
+func isAdminEmail(email string) bool {
+	for _, e := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		if e = strings.TrimSpace(e); e != "" && strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// rolePermissions resolves the permissions of a built-in or custom role
+func rolePermissions(db *gorm.DB, name string) ([]string, error) {
+	if perms, ok := auth.BuiltinRoles[name]; ok {
+		return perms, nil
+	}
+	if db != nil {
+		var role models.Role
+		if err := db.Where("name = ?", name).First(&role).Error; err != nil {
+			return nil, errRoleNotFound
+		}
+		return role.Permissions, nil
+	}
+
+	// In-memory mode (fallback)
+	for _, r := range models.Roles {
+		if r.Name == name {
+			return r.Permissions, nil
+		}
+	}
+	return nil, errRoleNotFound
+}
+
+// listRoles returns the built-in roles followed by the custom ones
+func listRoles(db *gorm.DB) ([]RoleResponse, error) {
+	roles := []RoleResponse{
+		{Name: auth.RoleUser, Permissions: auth.BuiltinRoles[auth.RoleUser], BuiltIn: true},
+		{Name: auth.RoleAdmin, Permissions: auth.BuiltinRoles[auth.RoleAdmin], BuiltIn: true},
+	}
+	custom := models.Roles
+	if db != nil {
+		custom = nil
+		if err := db.Order("name").Find(&custom).Error; err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range custom {
+		roles = append(roles, RoleResponse{Name: r.Name, Permissions: r.Permissions})
+	}
+	return roles, nil
+}
+
+// createRole stores a custom role
+func createRole(db *gorm.DB, role *models.Role) error {
+	if _, ok := auth.BuiltinRoles[role.Name]; ok {
+		return errRoleExists
+	}
+	if db != nil {
+		var count int64
+		if err := db.Model(&models.Role{}).Where("name = ?", role.Name).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errRoleExists
+		}
+		return db.Create(role).Error
+	}
+
+	// In-memory mode (fallback)
+	var max uint
+	for _, r := range models.Roles {
+		if r.Name == role.Name {
+			return errRoleExists
+		}
+		if r.ID > max {
+			max = r.ID
+		}
+	}
+	role.ID = max + 1
+	models.Roles = append(models.Roles, *role)
+	return nil
+}
+
+// deleteRole removes a custom role that no user has
+func deleteRole(db *gorm.DB, name string) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			var role models.Role
+			if err := tx.Where("name = ?", name).First(&role).Error; err != nil {
+				return errRoleNotFound
+			}
+			var users int64
+			if err := tx.Model(&models.User{}).Where("role = ?", name).Count(&users).Error; err != nil {
+				return err
+			}
+			if users > 0 {
+				return errRoleInUse
+			}
+			return tx.Delete(&role).Error
+		})
+	}
+
+	// In-memory mode (fallback)
+	i := slices.IndexFunc(models.Roles, func(r models.Role) bool { return r.Name == name })
+	if i < 0 {
+		return errRoleNotFound
+	}
+	if slices.ContainsFunc(models.Users, func(u models.User) bool { return u.Role == name }) {
+		return errRoleInUse
+	}
+	models.Roles = slices.Delete(models.Roles, i, i+1)
+	return nil
+}
+
+// listUsers returns all users ordered by ID
+func listUsers(db *gorm.DB) ([]models.User, error) {
+	users := []models.User{}
+	if db != nil {
+		err := db.Order("id").Find(&users).Error
+		return users, err
+	}
+
+	// In-memory mode (fallback)
+	return append(users, models.Users...), nil
+}
+
+// setUserRole changes a user's role. The caller, holding the granted permissions, must
+// have every permission of the user's current role, so nobody can demote someone more
+// privileged. The last admin can't be demoted, so the instance always keeps someone who
+// can manage users.
+func setUserRole(db *gorm.DB, userID uint, role string, granted []string) (*models.User, error) {
+	if db != nil {
+		var user models.User
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// Serialize role changes so two admins can't demote each other at once
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('user_roles'))").Error; err != nil {
+				return err
+			}
+			if err := tx.First(&user, userID).Error; err != nil {
+				return errUserNotFound
+			}
+			if err := checkRoleAllowed(tx, user.Role, granted); err != nil {
+				return err
+			}
+			if user.Role == auth.RoleAdmin && role != auth.RoleAdmin {
+				var admins int64
+				if err := tx.Model(&models.User{}).Where("role = ?", auth.RoleAdmin).Count(&admins).Error; err != nil {
+					return err
+				}
+				if admins <= 1 {
+					return errLastAdmin
+				}
+			}
+			user.Role = role
+			return tx.Model(&user).Update("role", role).Error
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &user, nil
+	}
+
+	// In-memory mode (fallback)
+	user, err := findUserByID(nil, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRoleAllowed(nil, user.Role, granted); err != nil {
+		return nil, err
+	}
+	if user.Role == auth.RoleAdmin && role != auth.RoleAdmin {
+		admins := 0
+		for _, u := range models.Users {
+			if u.Role == auth.RoleAdmin {
+				admins++
+			}
+		}
+		if admins <= 1 {
+			return nil, errLastAdmin
+		}
+	}
+	user.Role = role
+	return user, nil
+}
+
+// checkRoleAllowed reports errRoleNotAllowed unless granted covers every permission of
+// the role. It keeps role changes from handing out or taking away more than the caller has.
+func checkRoleAllowed(db *gorm.DB, role string, granted []string) error {
+	perms, err := rolePermissions(db, role)
+	if err != nil {
+		return err
+	}
+	if !auth.HasPermissions(granted, perms...) {
+		return errRoleNotAllowed
+	}
+	return nil
+}
+
+// deletePublicTask removes a public task (one without an owner)
+func deletePublicTask(db *gorm.DB, id uint) error {
+	if db != nil {
+		res := db.Where("id = ? AND user_id = 0", id).Delete(&models.Task{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errPublicNotFound
+		}
+		return nil
+	}
+
+	// In-memory mode (fallback)
+	i := slices.IndexFunc(models.PublicTasks, func(t models.Task) bool { return t.ID == id })
+	if i < 0 {
+		return errPublicNotFound
+	}
+	models.PublicTasks = slices.Delete(models.PublicTasks, i, i+1)
+	return nil
+}
@@ -1,7 +1,13 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"go_taskmanagement/database"
 	"go_taskmanagement/models"
@@ -9,6 +15,64 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+const (
+	defaultTaskPriority      = "medium"
+	defaultEstimateUnit      = "points"
+	maxTaskEstimate          = 10000
+	maxTaskTitleLength       = 200
+	maxTaskDescriptionLength = 1000
+	maxTaskTags              = 20
+	maxTaskTagLength         = 50
+
+	mimeMergePatchJSON = "application/merge-patch+json"
+)
+
+// TaskCreateRequest görev oluşturma isteği modeli
+type TaskCreateRequest struct {
+	Title        string                   `json:"title" example:"Rapor hazırla"`
+	Description  string                   `json:"description" example:"Aylık raporu tamamla"`
+	Status       string                   `json:"status" example:"pending"`
+	Priority     string                   `json:"priority" enums:"low,medium,high" example:"medium"`
+	ProjectID    *uint                    `json:"project_id,omitempty" example:"1"`
+	Estimate     *float64                 `json:"estimate,omitempty" example:"3"`
+	EstimateUnit string                   `json:"estimate_unit,omitempty" enums:"hours,points" example:"points"` // Varsayılan points
+	CustomFields models.CustomFieldValues `json:"custom_fields,omitempty" swaggertype:"object"`                  // Alan anahtarı -> değer
+	Tags         []string                 `json:"tags,omitempty" example:"onboarding"`
+	DueDate      *time.Time               `json:"due_date,omitempty" example:"2025-12-31T23:59:59Z"`
+	ParentID     *uint                    `json:"parent_id,omitempty" example:"1"` // Alt görev olarak eklenecek görev
+}
+
+// TaskReplaceRequest PUT ile görev değiştirme isteği modeli
+type TaskReplaceRequest struct {
+	Title        string                   `json:"title" example:"Rapor hazırla"`
+	Description  string                   `json:"description" example:"Aylık raporu tamamla"`
+	Status       string                   `json:"status" example:"in_progress"`
+	Priority     string                   `json:"priority" enums:"low,medium,high" example:"high"`
+	Estimate     *float64                 `json:"estimate,omitempty" example:"5"` // Gönderilmezse tahmin temizlenir
+	EstimateUnit string                   `json:"estimate_unit,omitempty" enums:"hours,points" example:"points"`
+	CustomFields models.CustomFieldValues `json:"custom_fields,omitempty" swaggertype:"object"` // Gönderilmeyen özel alanlar temizlenir
+	Tags         []string                 `json:"tags,omitempty" example:"onboarding"`          // Gönderilmezse etiketler temizlenir
+	DueDate      *time.Time               `json:"due_date,omitempty" example:"2025-12-31T23:59:59Z"`
+}
+
+// TaskPatchRequest PATCH ile görev güncelleme isteği modeli (JSON Merge Patch)
+type TaskPatchRequest struct {
+	Title        *string                `json:"title,omitempty" example:"Rapor hazırla"`
+	Description  *string                `json:"description,omitempty" extensions:"x-nullable" example:"Aylık raporu tamamla"`
+	Status       *string                `json:"status,omitempty" extensions:"x-nullable" example:"completed"`
+	Priority     *string                `json:"priority,omitempty" extensions:"x-nullable" enums:"low,medium,high" example:"low"`
+	Estimate     *float64               `json:"estimate,omitempty" extensions:"x-nullable" example:"8"`
+	EstimateUnit *string                `json:"estimate_unit,omitempty" extensions:"x-nullable" enums:"hours,points" example:"hours"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty" extensions:"x-nullable" swaggertype:"object"` // null gönderilen anahtar silinir
+	Tags         []string               `json:"tags,omitempty" extensions:"x-nullable" example:"onboarding"`          // Listenin tamamı değişir
+	DueDate      *time.Time             `json:"due_date,omitempty" extensions:"x-nullable" example:"2025-12-31T23:59:59Z"`
+}
+
+// TaskReopenRequest görevi yeniden açma isteği modeli
+type TaskReopenRequest struct {
+	Status string `json:"status,omitempty" example:"in_progress"`
+}
+
 // PublicTasksHandler herkese açık görevleri listeler
 // @ID PublicTasksHandler
 // @Summary Public görevleri listele
@@ -31,11 +95,14 @@ func PublicTasksHandler(c *fiber.Ctx) error {
 // TasksListHandler kullanıcının kendi görevlerini listeler
 // @ID TasksListHandler
 // @Summary Kullanıcı görevlerini listele
-// @Description Sadece giriş yapan kullanıcının görevlerini döner
+// @Description Sadece giriş yapan kullanıcının görevlerini döner. project_id ile projeye göre, cf.<alan>=<değer> ile özel alanlara göre filtrelenir; sort=cf.<alan> (azalan için -cf.<alan>) ile özel alana göre sıralanır. Özel alan filtresi ve sıralaması project_id gerektirir. sort verilmezse görevler pano sırasıyla (durum, rank, id) döner.
 // @Tags Tasks
 // @Produce json
 // @Security BearerAuth
+// @Param project_id query int false "Proje ID"
+// @Param sort query string false "Sıralama, örn. -cf.story_points"
 // @Success 200 {array} models.Task
+// @Failure 400 {object} map[string]string
 // @Router /tasks [get]
 func TasksListHandler(c *fiber.Ctx) error {
 	uid := c.Locals("user_id")
@@ -44,41 +111,46 @@ func TasksListHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
 	}
 
+	query, err := parseTaskListQuery(c, taskDB(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
 	if database.IsConnected && database.DB != nil {
 		var userTasks []models.Task
-		database.DB.Preload("User").Where("user_id = ?", userID).Find(&userTasks)
+		query.apply(database.DB.Preload("User").Where("user_id = ?", userID)).Find(&userTasks)
+		setComputedFields(database.DB, taskPointers(userTasks)...)
 		return c.JSON(userTasks)
 	}
 
 	// In-memory mode (fallback)
 	var userTasks []models.Task
 	for _, t := range models.Tasks {
-		if t.UserID == userID {
+		if t.UserID == userID && !t.DeletedAt.Valid {
 			userTasks = append(userTasks, t)
 		}
 	}
+	userTasks = query.filter(userTasks)
+	setComputedFields(nil, taskPointers(userTasks)...)
 	return c.JSON(userTasks)
 }
 
 // TaskCreateHandler yeni görev ekler
 // @ID TaskCreateHandler
 // @Summary Görev ekle
-// @Description Yeni görev oluşturur
+// @Description Yeni görev oluşturur. EMAIL_VERIFICATION=tasks ise email adresi doğrulanmamış kullanıcılar 403 alır.
 // @Tags Tasks
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param task body models.Task true "Görev"
+// @Param task body TaskCreateRequest true "Görev"
 // @Success 201 {object} models.Task
 // @Failure 400 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Failure 403 {object} map[string]string
 // @Router /tasks [post]
 func TaskCreateHandler(c *fiber.Ctx) error {
-	var input struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
-		Status      string `json:"status"`
-		Priority    string `json:"priority"`
-	}
+	var input TaskCreateRequest
 
 	if err := c.BodyParser(&input); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
@@ -93,47 +165,69 @@ func TaskCreateHandler(c *fiber.Ctx) error {
 	if !ok {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
 	}
+	if blocked, err := taskCreationBlocked(c, userID); blocked {
+		return err
+	}
 
-	// Set defaults
-	if input.Status == "" {
-		input.Status = "pending"
+	if errs := validateTaskProject(taskDB(), userID, input.ProjectID); len(errs) > 0 {
+		return validationFailed(c, errs)
 	}
-	if input.Priority == "" {
-		input.Priority = "medium"
+	task := newTask(userID, input)
+	if errs := validateNewTask(taskDB(), &task); len(errs) > 0 {
+		return validationFailed(c, errs)
 	}
 
-	task := models.Task{
-		UserID:      userID,
-		Title:       input.Title,
-		Description: input.Description,
-		Status:      input.Status,
-		Priority:    input.Priority,
+	if err := createTask(taskDB(), userID, &task); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev oluşturulamadı"})
 	}
 
-	if database.IsConnected && database.DB != nil {
-		if err := database.DB.Create(&task).Error; err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev oluşturulamadı"})
-		}
-		// Preload user information for the created task
-		database.DB.Preload("User").First(&task, task.ID)
-	} else {
-		// In-memory mode (fallback)
-		task.ID = uint(len(models.Tasks) + 1)
-		models.Tasks = append(models.Tasks, task)
+	return c.Status(fiber.StatusCreated).JSON(task)
+}
+
+// newTask builds a task for the user from a create request, filling in defaults
+func newTask(userID uint, input TaskCreateRequest) models.Task {
+	if input.Priority == "" {
+		input.Priority = defaultTaskPriority
+	}
+	if input.Estimate != nil && input.EstimateUnit == "" {
+		input.EstimateUnit = defaultEstimateUnit
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(task)
+	task := models.Task{
+		UserID:       userID,
+		ProjectID:    input.ProjectID,
+		Title:        input.Title,
+		Description:  input.Description,
+		Status:       input.Status,
+		Priority:     input.Priority,
+		Estimate:     input.Estimate,
+		EstimateUnit: input.EstimateUnit,
+		CustomFields: input.CustomFields,
+		Tags:         input.Tags,
+		DueDate:      input.DueDate,
+		ParentID:     input.ParentID,
+		Version:      1,
+	}
+	if task.Status == "" {
+		task.Status = taskWorkflow(&task).Initial()
+	}
+	return task
 }
 
 // TaskDetailHandler görev detayını döner
 // @ID TaskDetailHandler
 // @Summary Görev detayını görüntüle
-// @Description Belirli bir görevin detayını döner
+// @Description Belirli bir görevin detayını döner. Açıklama CommonMark olarak yorumlanır; description_tasks açıklamadaki "- [ ]" / "- [x]" kutularını sayar.
 // @Tags Tasks
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Görev ID"
+// @Param If-None-Match header string false "Önceki yanıttan alınan ETag"
+// @Param render query string false "html verilirse açıklama güvenli HTML olarak description_html alanında döner" Enums(html)
 // @Success 200 {object} models.Task
+// @Header 200 {string} ETag "Görevin güncel sürümü"
+// @Success 304 "Görev değişmedi"
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Router /tasks/{id} [get]
 func TaskDetailHandler(c *fiber.Ctx) error {
@@ -149,31 +243,52 @@ func TaskDetailHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
 	}
 
-	var task models.Task
-
-	if database.DB == nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database connection not available"})
+	render := c.Query("render")
+	if render != "" && render != "html" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "render yalnızca html olabilir"})
 	}
 
-	if err := database.DB.Preload("User").Where("id = ? AND user_id = ?", uint(id), userID).First(&task).Error; err != nil {
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
 	}
 
+	setComputedFields(taskDB(), task)
+	etag := taskETag(task)
+	c.Set(fiber.HeaderETag, etag)
+	if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag, false) {
+		c.Status(fiber.StatusNotModified)
+		return nil
+	}
+
+	if render == "html" {
+		html, err := renderMarkdown(task.Description)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Açıklama işlenemedi"})
+		}
+		task.DescriptionHTML = html
+	}
 	return c.JSON(task)
 }
 
-// TaskUpdateHandler görevi günceller
+// TaskUpdateHandler görevi tamamen değiştirir
 // @ID TaskUpdateHandler
 // @Summary Görev güncelle
-// @Description Belirli bir görevi günceller
+// @Description Belirli bir görevi gönderilen alanlarla tamamen değiştirir. Gönderilmeyen açıklama boş kabul edilir. Durum, görevin projesinin iş akışına uymalıdır. Açık engelleyen görevi olan görev force=true olmadan tamamlanamaz.
 // @Tags Tasks
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Görev ID"
-// @Param task body models.Task true "Görev"
+// @Param If-Match header string false "Görevin beklenen ETag değeri"
+// @Param force query bool false "Açık engelleyen görevler olsa da tamamla"
+// @Param task body TaskReplaceRequest true "Görev"
 // @Success 200 {object} models.Task
+// @Header 200 {string} ETag "Görevin güncel sürümü"
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
 // @Router /tasks/{id} [put]
 func TaskUpdateHandler(c *fiber.Ctx) error {
 	uid := c.Locals("user_id")
@@ -188,67 +303,340 @@ func TaskUpdateHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
 	}
 
-	var input struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
-		Status      string `json:"status"`
-		Priority    string `json:"priority"`
-	}
-
+	var input TaskReplaceRequest
 	if err := c.BodyParser(&input); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
 	}
 
-	// Validate title if provided
-	if input.Title == "" && input.Description == "" && input.Status == "" && input.Priority == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "En az bir alan güncellenmelidir"})
+	// PUT is a full replacement, so every required field must be present
+	if input.Title == "" || input.Status == "" || input.Priority == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Başlık, durum ve öncelik zorunlu"})
+	}
+	if err := validateTaskText(input.Title, input.Description); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	if !ifMatchSatisfied(c, task) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
+	}
+
+	if input.Estimate != nil && input.EstimateUnit == "" {
+		input.EstimateUnit = defaultEstimateUnit
+	}
+	updates := map[string]interface{}{
+		"estimate":      input.Estimate,
+		"estimate_unit": input.EstimateUnit,
+		"title":         input.Title,
+		"description":   input.Description,
+		"status":        input.Status,
+		"priority":      input.Priority,
+		"custom_fields": input.CustomFields,
+		"tags":          models.Tags(input.Tags),
+		"due_date":      input.DueDate,
+	}
+	if errs := validateTaskUpdate(task, updates); len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+	if errs := validateTaskBlockers(taskDB(), task, updates, c.QueryBool("force")); len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+	if err := saveTaskUpdates(taskDB(), userID, task, updates); err != nil {
+		if errors.Is(err, errTaskVersionConflict) {
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev güncellenemedi"})
+	}
+
+	setComputedFields(taskDB(), task)
+	c.Set(fiber.HeaderETag, taskETag(task))
+	return c.JSON(task)
+}
+
+// TaskPatchHandler görevi JSON Merge Patch (RFC 7396) ile kısmen günceller
+// @ID TaskPatchHandler
+// @Summary Görevi kısmen güncelle
+// @Description Gönderilmeyen alanlar değişmez, null gönderilen alanlar temizlenir (durum ve öncelik varsayılana döner). Başlık null olamaz.
+// @Tags Tasks
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param If-Match header string false "Görevin beklenen ETag değeri"
+// @Param force query bool false "Açık engelleyen görevler olsa da tamamla"
+// @Param task body TaskPatchRequest true "Değişecek alanlar"
+// @Success 200 {object} models.Task
+// @Header 200 {string} ETag "Görevin güncel sürümü"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /tasks/{id} [patch]
+func TaskPatchHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	idStr := c.Params("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
 	}
 
-	// Find the task
-	var task models.Task
+	ctype := strings.ToLower(strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0]))
+	if ctype != fiber.MIMEApplicationJSON && ctype != mimeMergePatchJSON {
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"error": "İçerik tipi application/merge-patch+json olmalı"})
+	}
 
-	if database.DB == nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database connection not available"})
+	// A merge patch that is not an object would replace the whole task, which we don't allow
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(c.Body(), &patch); err != nil || patch == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
 	}
 
-	if err := database.DB.Where("id = ? AND user_id = ?", uint(id), userID).First(&task).Error; err != nil {
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
 	}
+	if !ifMatchSatisfied(c, task) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
+	}
 
-	// Update fields
-	updates := make(map[string]interface{})
-	if input.Title != "" {
-		updates["title"] = input.Title
+	updates, err := mergePatchUpdates(task, patch)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
-	if input.Description != "" {
-		updates["description"] = input.Description
+
+	if err := validatePatchedTask(task, updates); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
-	if input.Status != "" {
-		updates["status"] = input.Status
+	if errs := validateTaskUpdate(task, updates); len(errs) > 0 {
+		return validationFailed(c, errs)
 	}
-	if input.Priority != "" {
-		updates["priority"] = input.Priority
+	if errs := validateTaskBlockers(taskDB(), task, updates, c.QueryBool("force")); len(errs) > 0 {
+		return validationFailed(c, errs)
 	}
 
-	if err := database.DB.Model(&task).Updates(updates).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev güncellenemedi"})
+	if len(updates) > 0 {
+		if err := saveTaskUpdates(taskDB(), userID, task, updates); err != nil {
+			if errors.Is(err, errTaskVersionConflict) {
+				return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev güncellenemedi"})
+		}
+	}
+
+	setComputedFields(taskDB(), task)
+	c.Set(fiber.HeaderETag, taskETag(task))
+	return c.JSON(task)
+}
+
+// TaskReopenHandler tamamlanmış görevi yeniden açar
+// @ID TaskReopenHandler
+// @Summary Görevi yeniden aç
+// @Description Tamamlanmış bir görevi iş akışının izin verdiği bir duruma geri alır. Durum gönderilmezse başlangıç durumu kullanılır.
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param If-Match header string false "Görevin beklenen ETag değeri"
+// @Param request body TaskReopenRequest false "Hedef durum"
+// @Success 200 {object} models.Task
+// @Header 200 {string} ETag "Görevin güncel sürümü"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /tasks/{id}/reopen [post]
+func TaskReopenHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	idStr := c.Params("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	var input TaskReopenRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+		}
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	if !ifMatchSatisfied(c, task) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
 	}
 
-	// Reload the task with user information
-	database.DB.Preload("User").First(&task, task.ID)
+	wf := taskWorkflow(task)
+	if input.Status == "" {
+		input.Status = wf.Initial()
+	}
+	if !wf.CanReopen(task.Status, input.Status) {
+		return validationFailed(c, fieldErrors{
+			"status": fmt.Sprintf("%s durumundaki görev %s durumuna yeniden açılamaz", task.Status, input.Status),
+		})
+	}
 
+	if err := saveTaskUpdates(taskDB(), userID, task, map[string]interface{}{"status": input.Status}); err != nil {
+		if errors.Is(err, errTaskVersionConflict) {
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev güncellenemedi"})
+	}
+
+	setComputedFields(taskDB(), task)
+	c.Set(fiber.HeaderETag, taskETag(task))
 	return c.JSON(task)
 }
 
+// mergePatchUpdates turns a merge patch document into column updates.
+// Absent members are left alone and null members reset the field; a null
+// status goes back to the initial status of the task's workflow.
+func mergePatchUpdates(task *models.Task, patch map[string]json.RawMessage) (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+	for key, raw := range patch {
+		isNull := string(raw) == "null"
+
+		// The estimate is the only numeric field
+		if key == "estimate" {
+			var estimate *float64
+			if err := json.Unmarshal(raw, &estimate); err != nil {
+				return nil, errors.New("estimate alanı sayı olmalı")
+			}
+			updates["estimate"] = estimate
+			if _, ok := patch["estimate_unit"]; !ok && estimate != nil && task.EstimateUnit == "" {
+				updates["estimate_unit"] = defaultEstimateUnit
+			}
+			continue
+		}
+
+		// Tags are replaced as a whole list
+		if key == "tags" {
+			var tags []string
+			if err := json.Unmarshal(raw, &tags); err != nil {
+				return nil, errors.New("tags alanı metin listesi olmalı")
+			}
+			updates["tags"] = models.Tags(tags)
+			continue
+		}
+
+		if key == "due_date" {
+			var due *time.Time
+			if err := json.Unmarshal(raw, &due); err != nil {
+				return nil, errors.New("due_date alanı RFC 3339 tarih olmalı")
+			}
+			updates["due_date"] = due
+			continue
+		}
+
+		// Custom fields are an object that is merged member by member
+		if key == "custom_fields" {
+			values, err := mergeCustomFields(task.CustomFields, raw)
+			if err != nil {
+				return nil, err
+			}
+			updates["custom_fields"] = values
+			continue
+		}
+
+		var value string
+		if !isNull {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, fmt.Errorf("%s alanı metin olmalı", key)
+			}
+		}
+
+		switch key {
+		case "title":
+			if isNull || value == "" {
+				return nil, errors.New("Başlık boş bırakılamaz")
+			}
+			updates["title"] = value
+		case "description":
+			updates["description"] = value
+		case "status":
+			if isNull {
+				value = taskWorkflow(task).Initial()
+			} else if value == "" {
+				return nil, errors.New("Durum boş bırakılamaz")
+			}
+			updates["status"] = value
+		case "priority":
+			if isNull {
+				value = defaultTaskPriority
+			} else if value == "" {
+				return nil, errors.New("Öncelik boş bırakılamaz")
+			}
+			updates["priority"] = value
+		case "estimate_unit":
+			if isNull {
+				value = defaultEstimateUnit
+			} else if value == "" {
+				return nil, errors.New("Tahmin birimi boş bırakılamaz")
+			}
+			updates["estimate_unit"] = value
+		default:
+			return nil, fmt.Errorf("Bilinmeyen alan: %s", key)
+		}
+	}
+	return updates, nil
+}
+
+// validatePatchedTask validates the task as it would look after the updates
+func validatePatchedTask(task *models.Task, updates map[string]interface{}) error {
+	title, description := task.Title, task.Description
+	if v, ok := updates["title"].(string); ok {
+		title = v
+	}
+	if v, ok := updates["description"].(string); ok {
+		description = v
+	}
+	return validateTaskText(title, description)
+}
+
+// validateTaskText checks title and description against the limits in the API spec
+func validateTaskText(title, description string) error {
+	if title == "" {
+		return errors.New("Başlık zorunlu")
+	}
+	if utf8.RuneCountInString(title) > maxTaskTitleLength {
+		return fmt.Errorf("Başlık en fazla %d karakter olabilir", maxTaskTitleLength)
+	}
+	if utf8.RuneCountInString(description) > maxTaskDescriptionLength {
+		return fmt.Errorf("Açıklama en fazla %d karakter olabilir", maxTaskDescriptionLength)
+	}
+	return nil
+}
+
 // TaskDeleteHandler görevi siler
 // @ID TaskDeleteHandler
 // @Summary Görev sil
-// @Description Belirli bir görevi siler
+// @Description Belirli bir görevi çöp kutusuna taşır. permanent=true ile görev (çöp kutusundaki dahil) kalıcı olarak silinir.
 // @Tags Tasks
 // @Security BearerAuth
 // @Param id path int true "Görev ID"
+// @Param permanent query bool false "Kalıcı olarak sil"
+// @Param If-Match header string false "Görevin beklenen ETag değeri"
 // @Success 200 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
 // @Router /tasks/{id} [delete]
 func TaskDeleteHandler(c *fiber.Ctx) error {
 	uid := c.Locals("user_id")
@@ -263,19 +651,33 @@ func TaskDeleteHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
 	}
 
-	// Check database connection
-	if database.DB == nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database connection not available"})
+	permanent := c.QueryBool("permanent", false)
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil && permanent {
+		// Permanent deletion also empties a task out of the trash
+		task, err = findTrashedTask(taskDB(), userID, uint(id))
+	}
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	if !ifMatchSatisfied(c, task) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
 	}
 
-	// Soft delete the task
-	result := database.DB.Where("id = ? AND user_id = ?", uint(id), userID).Delete(&models.Task{})
-	if result.Error != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev silinemedi"})
+	if permanent {
+		if err := purgeTask(taskDB(), userID, task); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev silinemedi"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Task permanently deleted"})
 	}
 
-	if result.RowsAffected == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	// Soft delete the task
+	if err := deleteTask(taskDB(), userID, task); err != nil {
+		if errors.Is(err, errTaskVersionConflict) {
+			return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Görev başka bir istekle değiştirilmiş"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev silinemedi"})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Task deleted successfully"})
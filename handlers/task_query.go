@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// customFieldFilter matches tasks whose custom field equals a value;
+// for multi-select fields the value has to be one of the selected options
+type customFieldFilter struct {
+	field models.CustomField
+	value interface{}
+}
+
+// taskListQuery is the parsed query string of the task list
+type taskListQuery struct {
+	projectID *uint
+	filters   []customFieldFilter
+	sortField *models.CustomField
+	sortDesc  bool
+}
+
+// parseTaskListQuery reads project_id, cf.<key>=<value> filters and sort=[-]cf.<key>.
+// Custom fields are only meaningful within a project, so they need project_id.
+func parseTaskListQuery(c *fiber.Ctx, db *gorm.DB, userID uint) (taskListQuery, error) {
+	var q taskListQuery
+
+	if raw := c.Query("project_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return q, errors.New("Geçersiz proje ID")
+		}
+		if _, err := findUserProject(db, userID, uint(id)); err != nil {
+			return q, errors.New("Proje bulunamadı veya yetkiniz yok")
+		}
+		pid := uint(id)
+		q.projectID = &pid
+	}
+
+	sortParam := c.Query("sort")
+	filterKeys := make([]string, 0)
+	for key := range c.Queries() {
+		if strings.HasPrefix(key, customFieldQueryPrefix) {
+			filterKeys = append(filterKeys, key)
+		}
+	}
+	if len(filterKeys) == 0 && sortParam == "" {
+		return q, nil
+	}
+	if q.projectID == nil {
+		return q, errors.New("Özel alanlara göre filtreleme ve sıralama için project_id gerekli")
+	}
+
+	defs, err := projectCustomFields(db, *q.projectID)
+	if err != nil {
+		return q, err
+	}
+	lookup := func(key string) (models.CustomField, bool) {
+		for _, d := range defs {
+			if d.Key == key {
+				return d, true
+			}
+		}
+		return models.CustomField{}, false
+	}
+
+	slices.Sort(filterKeys)
+	for _, key := range filterKeys {
+		def, ok := lookup(strings.TrimPrefix(key, customFieldQueryPrefix))
+		if !ok {
+			return q, fmt.Errorf("Bilinmeyen özel alan: %s", key)
+		}
+		value, err := parseCustomFieldFilter(def, c.Query(key))
+		if err != nil {
+			return q, fmt.Errorf("%s: %v", key, err)
+		}
+		q.filters = append(q.filters, customFieldFilter{field: def, value: value})
+	}
+
+	if sortParam != "" {
+		q.sortDesc = strings.HasPrefix(sortParam, "-")
+		name := strings.TrimPrefix(sortParam, "-")
+		if !strings.HasPrefix(name, customFieldQueryPrefix) {
+			return q, errors.New("sort yalnızca cf.<alan> veya -cf.<alan> olabilir")
+		}
+		def, ok := lookup(strings.TrimPrefix(name, customFieldQueryPrefix))
+		if !ok {
+			return q, fmt.Errorf("Bilinmeyen özel alan: %s", name)
+		}
+		if def.Type == models.CustomFieldMultiSelect {
+			return q, errors.New("Çoklu seçim alanlarına göre sıralanamaz")
+		}
+		q.sortField = &def
+	}
+	return q, nil
+}
+
+// parseCustomFieldFilter converts a query string value to the field's type
+func parseCustomFieldFilter(def models.CustomField, raw string) (interface{}, error) {
+	switch def.Type {
+	case models.CustomFieldNumber:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errors.New("sayı olmalı")
+		}
+		return n, nil
+	case models.CustomFieldUser:
+		n, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return nil, errors.New("kullanıcı ID olmalı")
+		}
+		return float64(n), nil
+	case models.CustomFieldDate:
+		if _, err := time.Parse(customFieldDateLayout, raw); err != nil {
+			return nil, errors.New("YYYY-MM-DD biçiminde tarih olmalı")
+		}
+	}
+	return raw, nil
+}
+
+// apply adds the query to a database query on tasks. Field keys are safe to
+// inline because definitions only accept customFieldKeyPattern keys.
+func (q taskListQuery) apply(query *gorm.DB) *gorm.DB {
+	if q.projectID != nil {
+		query = query.Where("project_id = ?", *q.projectID)
+	}
+	for _, f := range q.filters {
+		switch f.field.Type {
+		case models.CustomFieldNumber, models.CustomFieldUser:
+			query = query.Where(fmt.Sprintf("(custom_fields->>'%s')::numeric = ?", f.field.Key), f.value)
+		case models.CustomFieldMultiSelect:
+			contains, _ := json.Marshal([]interface{}{f.value})
+			query = query.Where(fmt.Sprintf("custom_fields->'%s' @> ?::jsonb", f.field.Key), string(contains))
+		default:
+			query = query.Where(fmt.Sprintf("custom_fields->>'%s' = ?", f.field.Key), f.value)
+		}
+	}
+	if q.sortField != nil {
+		expr := fmt.Sprintf("custom_fields->>'%s'", q.sortField.Key)
+		if t := q.sortField.Type; t == models.CustomFieldNumber || t == models.CustomFieldUser {
+			expr = "(" + expr + ")::numeric"
+		}
+		dir := "ASC"
+		if q.sortDesc {
+			dir = "DESC"
+		}
+		query = query.Order(fmt.Sprintf("%s %s NULLS LAST", expr, dir))
+	} else {
+		// Board order: tasks are grouped by status column and come by rank within it
+		query = query.Order("status").Order("rank")
+	}
+	return query.Order("id")
+}
+
+// filter applies the query to in-memory tasks
+func (q taskListQuery) filter(tasks []models.Task) []models.Task {
+	out := make([]models.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if q.matches(t) {
+			out = append(out, t)
+		}
+	}
+	if q.sortField == nil {
+		sort.SliceStable(out, func(i, j int) bool {
+			if out[i].Status != out[j].Status {
+				return out[i].Status < out[j].Status
+			}
+			if out[i].Rank != out[j].Rank {
+				return out[i].Rank < out[j].Rank
+			}
+			return out[i].ID < out[j].ID
+		})
+	} else {
+		key, numeric := q.sortField.Key, q.sortField.Type == models.CustomFieldNumber || q.sortField.Type == models.CustomFieldUser
+		sort.SliceStable(out, func(i, j int) bool {
+			a, aok := out[i].CustomFields[key]
+			b, bok := out[j].CustomFields[key]
+			// Tasks without a value come last in both directions
+			if !aok || !bok {
+				return aok && !bok
+			}
+			cmp := 0
+			if numeric {
+				cmp = compareFloat(customFieldNumber(a), customFieldNumber(b))
+			} else {
+				cmp = strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+			}
+			if q.sortDesc {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+	}
+	return out
+}
+
+func (q taskListQuery) matches(t models.Task) bool {
+	if q.projectID != nil && (t.ProjectID == nil || *t.ProjectID != *q.projectID) {
+		return false
+	}
+	for _, f := range q.filters {
+		value, ok := t.CustomFields[f.field.Key]
+		if !ok {
+			return false
+		}
+		switch f.field.Type {
+		case models.CustomFieldNumber, models.CustomFieldUser:
+			if customFieldNumber(value) != f.value.(float64) {
+				return false
+			}
+		case models.CustomFieldMultiSelect:
+			if !slices.Contains(customFieldStrings(value), f.value.(string)) {
+				return false
+			}
+		default:
+			if s, _ := value.(string); s != f.value.(string) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// customFieldNumber reads a number value that may be stored as float64 or, for user fields, uint
+func customFieldNumber(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case uint:
+		return float64(n)
+	}
+	return 0
+}
+
+// customFieldStrings reads a multi-select value stored as []string or decoded JSON
+func customFieldStrings(v interface{}) []string {
+	switch s := v.(type) {
+	case []string:
+		return s
+	case []interface{}:
+		out := make([]string, 0, len(s))
+		for _, item := range s {
+			if str, ok := item.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
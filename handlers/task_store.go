@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"go_taskmanagement/database"
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	errTaskNotFound        = errors.New("task not found")
+	errTaskVersionConflict = errors.New("task was modified concurrently")
+)
+
+// The helpers below take the *gorm.DB to run against so that callers can pass
+// a transaction. A nil db means the in-memory store is used.
+
+// taskDB returns the database handle, or nil when running in in-memory mode
+func taskDB() *gorm.DB {
+	if database.IsConnected && database.DB != nil {
+		return database.DB
+	}
+	return nil
+}
+
+// findUserTask loads a task owned by the user from the database or the in-memory store
+func findUserTask(db *gorm.DB, userID, id uint) (*models.Task, error) {
+	if db != nil {
+		var task models.Task
+		if err := db.Preload("User").Where("id = ? AND user_id = ?", id, userID).First(&task).Error; err != nil {
+			return nil, err
+		}
+		return &task, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.Tasks {
+		if models.Tasks[i].ID == id && models.Tasks[i].UserID == userID && !models.Tasks[i].DeletedAt.Valid {
+			return &models.Tasks[i], nil
+		}
+	}
+	return nil, errTaskNotFound
+}
+
+// createTask stores a new task and loads its user information
+func createTask(db *gorm.DB, actorID uint, task *models.Task) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			// New tasks go to the end of their status column
+			r, err := rankInColumn(tx, task, task.Status, nil, nil)
+			if err != nil {
+				return err
+			}
+			task.Rank = r
+			if err := tx.Create(task).Error; err != nil {
+				return err
+			}
+			if err := recordTaskActivity(tx, task.ID, actorID, models.ActivityCreated, "", "", task.Title); err != nil {
+				return err
+			}
+			// Preload user information for the created task
+			return tx.Preload("User").First(task, task.ID).Error
+		})
+	}
+
+	// In-memory mode (fallback)
+	r, err := rankInColumn(nil, task, task.Status, nil, nil)
+	if err != nil {
+		return err
+	}
+	task.Rank = r
+	now := time.Now()
+	task.ID = nextTaskID()
+	task.CreatedAt = now
+	task.UpdatedAt = now
+	models.Tasks = append(models.Tasks, *task)
+	return recordTaskActivity(nil, task.ID, actorID, models.ActivityCreated, "", "", task.Title)
+}
+
+// saveTaskUpdates applies column updates to the task and refreshes it in place.
+// Every changed field is recorded in the task history. A status change without
+// a rank moves the task to the end of its new column.
+func saveTaskUpdates(db *gorm.DB, actorID uint, task *models.Task, updates map[string]interface{}) error {
+	before := *task
+
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := placeOnStatusChange(tx, task, updates); err != nil {
+				return err
+			}
+			// A map is used so that zero values such as an empty description are written too.
+			// The version guard makes a concurrent writer lose instead of being overwritten.
+			updates["version"] = gorm.Expr("version + 1")
+			result := tx.Model(task).Where("version = ?", task.Version).Updates(updates)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errTaskVersionConflict
+			}
+			// Reload the task with user information
+			if err := tx.Preload("User").First(task, task.ID).Error; err != nil {
+				return err
+			}
+			return recordTaskChanges(tx, actorID, before, *task)
+		})
+	}
+
+	// In-memory mode (fallback)
+	if err := placeOnStatusChange(nil, task, updates); err != nil {
+		return err
+	}
+	for key, value := range updates {
+		switch key {
+		case "title":
+			task.Title = value.(string)
+		case "description":
+			task.Description = value.(string)
+		case "status":
+			task.Status = value.(string)
+		case "priority":
+			task.Priority = value.(string)
+		case "estimate":
+			task.Estimate = value.(*float64)
+		case "estimate_unit":
+			task.EstimateUnit = value.(string)
+		case "rank":
+			task.Rank = value.(string)
+		case "tags":
+			task.Tags = value.(models.Tags)
+		case "due_date":
+			task.DueDate = value.(*time.Time)
+		case "custom_fields":
+			task.CustomFields = value.(models.CustomFieldValues)
+		}
+	}
+	task.Version++
+	task.UpdatedAt = time.Now()
+	return recordTaskChanges(nil, actorID, before, *task)
+}
+
+// deleteTask soft deletes the task as long as nobody changed it since it was loaded
+func deleteTask(db *gorm.DB, actorID uint, task *models.Task) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Where("version = ?", task.Version).Delete(task)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errTaskVersionConflict
+			}
+			return recordTaskActivity(tx, task.ID, actorID, models.ActivityDeleted, "", "", "")
+		})
+	}
+
+	// In-memory mode (fallback)
+	task.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return recordTaskActivity(nil, task.ID, actorID, models.ActivityDeleted, "", "", "")
+}
+
+// findTrashedTask loads a soft-deleted task owned by the user
+func findTrashedTask(db *gorm.DB, userID, id uint) (*models.Task, error) {
+	if db != nil {
+		var task models.Task
+		err := db.Unscoped().Preload("User").
+			Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).
+			First(&task).Error
+		if err != nil {
+			return nil, err
+		}
+		return &task, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.Tasks {
+		if models.Tasks[i].ID == id && models.Tasks[i].UserID == userID && models.Tasks[i].DeletedAt.Valid {
+			return &models.Tasks[i], nil
+		}
+	}
+	return nil, errTaskNotFound
+}
+
+// restoreTask brings a soft-deleted task back and bumps its version
+func restoreTask(db *gorm.DB, actorID uint, task *models.Task) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Unscoped().Model(task).Updates(map[string]interface{}{
+				"deleted_at": nil,
+				"version":    gorm.Expr("version + 1"),
+			}).Error
+			if err != nil {
+				return err
+			}
+			if err := recordTaskActivity(tx, task.ID, actorID, models.ActivityRestored, "", "", ""); err != nil {
+				return err
+			}
+			return tx.Preload("User").First(task, task.ID).Error
+		})
+	}
+
+	// In-memory mode (fallback)
+	task.DeletedAt = gorm.DeletedAt{}
+	task.Version++
+	task.UpdatedAt = time.Now()
+	return recordTaskActivity(nil, task.ID, actorID, models.ActivityRestored, "", "", "")
+}
+
+// purgeTask removes the task for good, whether or not it is in the trash.
+// Its history is kept so the purge itself stays auditable.
+func purgeTask(db *gorm.DB, actorID uint, task *models.Task) error {
+	if db != nil {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := database.PurgeTasks(tx, task.ID); err != nil {
+				return err
+			}
+			return recordTaskActivity(tx, task.ID, actorID, models.ActivityPurged, "", "", "")
+		})
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.Tasks {
+		if models.Tasks[i].ID == task.ID {
+			models.Tasks = append(models.Tasks[:i], models.Tasks[i+1:]...)
+			deleteTaskDependencies(task.ID)
+			deleteTaskChecklist(task.ID)
+			stopTaskTimers(task.ID)
+			return recordTaskActivity(nil, task.ID, actorID, models.ActivityPurged, "", "", "")
+		}
+	}
+	return errTaskNotFound
+}
+
+// nextTaskID returns a free id for the in-memory store; ids stay unique after purges
+func nextTaskID() uint {
+	var max uint
+	for _, t := range models.Tasks {
+		if t.ID > max {
+			max = t.ID
+		}
+	}
+	return max + 1
+}
+
+// setComputedFields fills in the task fields that are derived from other records
+func setComputedFields(db *gorm.DB, tasks ...*models.Task) {
+	if len(tasks) == 0 {
+		return
+	}
+	setBlockedFlags(db, tasks...)
+	setTrackedTime(db, tasks...)
+	setChecklistCounts(db, tasks...)
+	setDescriptionTasks(tasks...)
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go_taskmanagement/models"
+	"go_taskmanagement/workflow"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// ValidationErrorResponse alan bazlı doğrulama hatası yanıtı
+type ValidationErrorResponse struct {
+	Error  string            `json:"error" example:"Doğrulama hatası"`
+	Fields map[string]string `json:"fields"`
+}
+
+// fieldErrors maps a request field to what is wrong with it
+type fieldErrors map[string]string
+
+// Error joins the field errors in a stable order, for places that only carry a single message
+func (e fieldErrors) Error() string {
+	parts := make([]string, 0, len(e))
+	for field, msg := range e {
+		parts = append(parts, field+": "+msg)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "; ")
+}
+
+// validationFailed writes a 422 response listing the invalid fields
+func validationFailed(c *fiber.Ctx, errs fieldErrors) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(ValidationErrorResponse{
+		Error:  "Doğrulama hatası",
+		Fields: errs,
+	})
+}
+
+// taskWorkflow returns the workflow of the task's project, or the default
+// workflow for tasks that don't belong to a project
+func taskWorkflow(task *models.Task) *workflow.Workflow {
+	if task.ProjectID != nil {
+		if project, err := findProject(taskDB(), *task.ProjectID); err == nil {
+			return &project.Workflow
+		}
+	}
+	return workflow.Default()
+}
+
+// validateTaskProject checks that a task may be created in the project
+func validateTaskProject(db *gorm.DB, userID uint, projectID *uint) fieldErrors {
+	if projectID == nil {
+		return nil
+	}
+	if _, err := findUserProject(db, userID, *projectID); err != nil {
+		return fieldErrors{"project_id": "Proje bulunamadı veya yetkiniz yok"}
+	}
+	return nil
+}
+
+// validateTaskEnums checks status and priority against the allowed values
+func validateTaskEnums(wf *workflow.Workflow, status, priority string) fieldErrors {
+	errs := fieldErrors{}
+	if !wf.HasStatus(status) {
+		errs["status"] = fmt.Sprintf("Geçersiz durum, izin verilenler: %s", strings.Join(wf.Statuses, ", "))
+	}
+	if !slices.Contains(models.TaskPriorities, priority) {
+		errs["priority"] = fmt.Sprintf("Geçersiz öncelik, izin verilenler: %s", strings.Join(models.TaskPriorities, ", "))
+	}
+	return errs
+}
+
+// validateTaskEstimate checks the estimate range and unit
+func validateTaskEstimate(estimate *float64, unit string) fieldErrors {
+	errs := fieldErrors{}
+	if estimate != nil && (*estimate < 0 || *estimate > maxTaskEstimate) {
+		errs["estimate"] = fmt.Sprintf("Tahmin 0 ile %d arasında olmalı", maxTaskEstimate)
+	}
+	if unit != "" && !slices.Contains(models.EstimateUnits, unit) {
+		errs["estimate_unit"] = fmt.Sprintf("Geçersiz tahmin birimi, izin verilenler: %s", strings.Join(models.EstimateUnits, ", "))
+	}
+	return errs
+}
+
+// validateTaskUpdate checks the values in updates and that the status change follows the workflow
+func validateTaskUpdate(task *models.Task, updates map[string]interface{}) fieldErrors {
+	wf := taskWorkflow(task)
+
+	status, priority := task.Status, task.Priority
+	if v, ok := updates["status"].(string); ok {
+		status = v
+	}
+	if v, ok := updates["priority"].(string); ok {
+		priority = v
+	}
+	estimate, unit := task.Estimate, task.EstimateUnit
+	if v, ok := updates["estimate"].(*float64); ok {
+		estimate = v
+	}
+	if v, ok := updates["estimate_unit"].(string); ok {
+		unit = v
+	}
+
+	errs := validateTaskEnums(wf, status, priority)
+	for field, msg := range validateTaskEstimate(estimate, unit) {
+		errs[field] = msg
+	}
+	if _, invalid := errs["status"]; !invalid && !wf.CanTransition(task.Status, status) {
+		if wf.CanReopen(task.Status, status) {
+			errs["status"] = fmt.Sprintf("%s durumundaki görev yalnızca yeniden açılarak %s yapılabilir", task.Status, status)
+		} else {
+			errs["status"] = fmt.Sprintf("%s durumundan %s durumuna geçiş yapılamaz", task.Status, status)
+		}
+	}
+
+	if tags, ok := updates["tags"].(models.Tags); ok {
+		normalized, msg := normalizeTaskTags(tags)
+		if msg != "" {
+			errs["tags"] = msg
+		}
+		updates["tags"] = normalized
+	}
+
+	if values, ok := updates["custom_fields"].(models.CustomFieldValues); ok {
+		normalized, fieldErrs := validateTaskCustomFields(taskDB(), task, values)
+		for field, msg := range fieldErrs {
+			errs[field] = msg
+		}
+		updates["custom_fields"] = normalized
+	}
+	return errs
+}
+
+// validateNewTask checks the enums and custom fields of a task about to be created
+func validateNewTask(db *gorm.DB, task *models.Task) fieldErrors {
+	errs := validateTaskEnums(taskWorkflow(task), task.Status, task.Priority)
+	for field, msg := range validateTaskEstimate(task.Estimate, task.EstimateUnit) {
+		errs[field] = msg
+	}
+	if tags, msg := normalizeTaskTags(task.Tags); msg != "" {
+		errs["tags"] = msg
+	} else {
+		task.Tags = tags
+	}
+	if task.ParentID != nil {
+		if _, err := findUserTask(db, task.UserID, *task.ParentID); err != nil {
+			errs["parent_id"] = "Üst görev bulunamadı veya yetkiniz yok"
+		}
+	}
+	normalized, fieldErrs := validateTaskCustomFields(db, task, task.CustomFields)
+	for field, msg := range fieldErrs {
+		errs[field] = msg
+	}
+	task.CustomFields = normalized
+	return errs
+}
+
+// normalizeTaskTags trims and de-duplicates tags, keeping their order.
+// It returns a message when the tags are invalid.
+func normalizeTaskTags(tags models.Tags) (models.Tags, string) {
+	if tags == nil {
+		return nil, ""
+	}
+	out := make(models.Tags, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return nil, "Etiket boş olamaz"
+		}
+		if utf8.RuneCountInString(tag) > maxTaskTagLength {
+			return nil, fmt.Sprintf("Etiketler en fazla %d karakter olabilir", maxTaskTagLength)
+		}
+		if !slices.Contains(out, tag) {
+			out = append(out, tag)
+		}
+	}
+	if len(out) > maxTaskTags {
+		return nil, fmt.Sprintf("En fazla %d etiket eklenebilir", maxTaskTags)
+	}
+	return out, ""
+}
@@ -0,0 +1,393 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	maxTemplateNameLength = 100
+	maxTemplateTasks      = 50
+	maxTemplateDepth      = 3
+	maxTemplateDueInDays  = 3650
+
+	// templateDateVariable is always available and holds the start date
+	templateDateVariable = "date"
+)
+
+// templateVariablePattern matches {{name}} placeholders, spaces inside the braces allowed
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// TemplateCreateRequest görev şablonu oluşturma isteği modeli
+type TemplateCreateRequest struct {
+	Name      string              `json:"name" example:"Yeni çalışan"`
+	ProjectID *uint               `json:"project_id,omitempty" example:"1"`
+	Task      models.TemplateTask `json:"task"`
+}
+
+// TemplateResponse görev şablonu ve kullandığı değişkenler
+type TemplateResponse struct {
+	models.TaskTemplate
+	Variables []string `json:"variables" example:"name"` // Şablonda geçen {{değişken}} adları
+}
+
+// TemplateInstantiateRequest şablondan görev oluşturma isteği modeli
+type TemplateInstantiateRequest struct {
+	Variables map[string]string `json:"variables,omitempty"`                       // Değişken adı -> değer
+	StartDate string            `json:"start_date,omitempty" example:"2025-09-01"` // due_in_days bu güne eklenir; varsayılan bugün
+}
+
+// TemplateInstantiateResponse şablondan oluşturulan görevler
+type TemplateInstantiateResponse struct {
+	Tasks []models.Task `json:"tasks"` // Önce ana görev, sonra alt görevler
+}
+
+// TemplatesListHandler kullanıcının görev şablonlarını listeler
+// @ID TemplatesListHandler
+// @Summary Görev şablonlarını listele
+// @Description Giriş yapan kullanıcının görev şablonlarını döner
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} TemplateResponse
+// @Router /templates [get]
+func TemplatesListHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	templates, err := userTemplates(taskDB(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Şablonlar alınamadı"})
+	}
+	resp := make([]TemplateResponse, 0, len(templates))
+	for _, tmpl := range templates {
+		resp = append(resp, templateResponse(tmpl))
+	}
+	return c.JSON(resp)
+}
+
+// TemplateCreateHandler yeni görev şablonu oluşturur
+// @ID TemplateCreateHandler
+// @Summary Görev şablonu oluştur
+// @Description Başlık, açıklama, öncelik, etiketler, kontrol listesi, göreli bitiş günü ve alt görevlerden oluşan bir şablon tanımlar. Metinlerde {{değişken}} yer tutucuları kullanılabilir; {{date}} başlangıç gününü verir.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param template body TemplateCreateRequest true "Şablon"
+// @Success 201 {object} TemplateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /templates [post]
+func TemplateCreateHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	var input TemplateCreateRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || utf8.RuneCountInString(input.Name) > maxTemplateNameLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Şablon adı zorunlu ve en fazla %d karakter olabilir", maxTemplateNameLength)})
+	}
+
+	errs := validateTaskProject(taskDB(), userID, input.ProjectID)
+	if errs == nil {
+		errs = fieldErrors{}
+	}
+	count := 0
+	validateTemplateTask(&input.Task, "task", 1, &count, errs)
+	if count > maxTemplateTasks {
+		errs["task"] = fmt.Sprintf("Şablon en fazla %d görev içerebilir", maxTemplateTasks)
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	tmpl := models.TaskTemplate{UserID: userID, Name: input.Name, ProjectID: input.ProjectID, Task: input.Task}
+	if err := createTemplate(taskDB(), &tmpl); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Şablon oluşturulamadı"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(templateResponse(tmpl))
+}
+
+// TemplateDetailHandler görev şablonunu döner
+// @ID TemplateDetailHandler
+// @Summary Görev şablonu detayı
+// @Description Şablonu ve içinde geçen değişken adlarını döner
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Şablon ID"
+// @Success 200 {object} TemplateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /templates/{id} [get]
+func TemplateDetailHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz şablon ID"})
+	}
+	tmpl, err := findUserTemplate(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Şablon bulunamadı veya yetkiniz yok"})
+	}
+	return c.JSON(templateResponse(*tmpl))
+}
+
+// TemplateDeleteHandler görev şablonunu siler
+// @ID TemplateDeleteHandler
+// @Summary Görev şablonunu sil
+// @Description Şablonu siler; şablondan daha önce oluşturulan görevler kalır
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Şablon ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /templates/{id} [delete]
+func TemplateDeleteHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz şablon ID"})
+	}
+	tmpl, err := findUserTemplate(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Şablon bulunamadı veya yetkiniz yok"})
+	}
+	if err := deleteTemplate(taskDB(), tmpl); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Şablon silinemedi"})
+	}
+	return c.JSON(fiber.Map{"message": "Şablon silindi"})
+}
+
+// TemplateInstantiateHandler şablondan görevleri oluşturur
+// @ID TemplateInstantiateHandler
+// @Summary Şablondan görev oluştur
+// @Description Şablondaki ana görevi, alt görevleri ve kontrol listelerini tek transaction içinde oluşturur. Değişkenler metinlere yerleştirilir, due_in_days başlangıç gününe eklenerek bitiş tarihi hesaplanır. Eksik değişken ya da geçersiz bir görev olursa hiçbir şey oluşturulmaz. EMAIL_VERIFICATION=tasks ise email adresi doğrulanmamış kullanıcılar 403 alır.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Şablon ID"
+// @Param request body TemplateInstantiateRequest false "Değişkenler ve başlangıç günü"
+// @Success 201 {object} TemplateInstantiateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Failure 403 {object} map[string]string
+// @Router /templates/{id}/instantiate [post]
+func TemplateInstantiateHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	if blocked, err := taskCreationBlocked(c, userID); blocked {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz şablon ID"})
+	}
+
+	var input TemplateInstantiateRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+		}
+	}
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	if input.StartDate != "" {
+		if start, err = time.Parse(customFieldDateLayout, input.StartDate); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "start_date YYYY-MM-DD biçiminde olmalı"})
+		}
+	}
+
+	tmpl, err := findUserTemplate(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Şablon bulunamadı veya yetkiniz yok"})
+	}
+
+	vars := map[string]string{templateDateVariable: start.Format(customFieldDateLayout)}
+	for name, value := range input.Variables {
+		vars[name] = value
+	}
+	errs := fieldErrors{}
+	for _, name := range templateVariables(tmpl.Task) {
+		if _, ok := vars[name]; !ok {
+			errs["variables."+name] = "Değişken verilmedi"
+		}
+	}
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	plan, errs := planTemplate(taskDB(), userID, tmpl, vars, start)
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+	tasks, err := instantiateTemplate(taskDB(), userID, plan)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görevler oluşturulamadı"})
+	}
+
+	setComputedFields(taskDB(), taskPointers(tasks)...)
+	return c.Status(fiber.StatusCreated).JSON(TemplateInstantiateResponse{Tasks: tasks})
+}
+
+// templateResponse adds the variables used by the template
+func templateResponse(tmpl models.TaskTemplate) TemplateResponse {
+	return TemplateResponse{TaskTemplate: tmpl, Variables: templateVariables(tmpl.Task)}
+}
+
+// validateTemplateTask checks a template task and its subtasks, adding errors under path.
+// Texts are checked before substitution; titles are checked again once filled in.
+func validateTemplateTask(t *models.TemplateTask, path string, depth int, count *int, errs fieldErrors) {
+	*count++
+	t.Title = strings.TrimSpace(t.Title)
+	if err := validateTaskText(t.Title, t.Description); err != nil {
+		errs[path+".title"] = err.Error()
+	}
+	if t.Priority != "" && !slices.Contains(models.TaskPriorities, t.Priority) {
+		errs[path+".priority"] = fmt.Sprintf("Geçersiz öncelik, izin verilenler: %s", strings.Join(models.TaskPriorities, ", "))
+	}
+	if tags, msg := normalizeTaskTags(t.Tags); msg != "" {
+		errs[path+".tags"] = msg
+	} else {
+		t.Tags = tags
+	}
+	if len(t.Checklist) > maxChecklistItems {
+		errs[path+".checklist"] = fmt.Sprintf("Kontrol listesi en fazla %d madde içerebilir", maxChecklistItems)
+	}
+	for i, text := range t.Checklist {
+		t.Checklist[i] = strings.TrimSpace(text)
+		if err := validateChecklistText(t.Checklist[i]); err != nil {
+			errs[fmt.Sprintf("%s.checklist[%d]", path, i)] = err.Error()
+		}
+	}
+	if t.DueInDays != nil && (*t.DueInDays < 0 || *t.DueInDays > maxTemplateDueInDays) {
+		errs[path+".due_in_days"] = fmt.Sprintf("due_in_days 0 ile %d arasında olmalı", maxTemplateDueInDays)
+	}
+	if len(t.Subtasks) > 0 && depth >= maxTemplateDepth {
+		errs[path+".subtasks"] = fmt.Sprintf("Alt görevler en fazla %d seviye olabilir", maxTemplateDepth-1)
+		return
+	}
+	for i := range t.Subtasks {
+		validateTemplateTask(&t.Subtasks[i], fmt.Sprintf("%s.subtasks[%d]", path, i), depth+1, count, errs)
+	}
+}
+
+// templateVariables lists the variable names used in the template texts, in order of appearance
+func templateVariables(t models.TemplateTask) []string {
+	names := []string{}
+	var walk func(t models.TemplateTask)
+	walk = func(t models.TemplateTask) {
+		texts := append([]string{t.Title, t.Description}, t.Checklist...)
+		for _, text := range texts {
+			for _, m := range templateVariablePattern.FindAllStringSubmatch(text, -1) {
+				if m[1] != templateDateVariable && !slices.Contains(names, m[1]) {
+					names = append(names, m[1])
+				}
+			}
+		}
+		for _, sub := range t.Subtasks {
+			walk(sub)
+		}
+	}
+	walk(t)
+	return names
+}
+
+// substituteVariables fills the placeholders in text; every variable must be present in vars
+func substituteVariables(text string, vars map[string]string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(m string) string {
+		return vars[templateVariablePattern.FindStringSubmatch(m)[1]]
+	})
+}
+
+// planTemplate turns the template into the tasks to create, parents first, and
+// validates each of them like a task created through the API
+func planTemplate(db *gorm.DB, userID uint, tmpl *models.TaskTemplate, vars map[string]string, start time.Time) ([]plannedTask, fieldErrors) {
+	var plan []plannedTask
+	errs := fieldErrors{}
+
+	var add func(t models.TemplateTask, path string, parent int)
+	add = func(t models.TemplateTask, path string, parent int) {
+		input := TaskCreateRequest{
+			Title:       substituteVariables(t.Title, vars),
+			Description: substituteVariables(t.Description, vars),
+			Priority:    t.Priority,
+			ProjectID:   tmpl.ProjectID,
+			Tags:        t.Tags,
+		}
+		if t.DueInDays != nil {
+			due := start.AddDate(0, 0, *t.DueInDays)
+			input.DueDate = &due
+		}
+		if err := validateTaskText(input.Title, input.Description); err != nil {
+			errs[path+".title"] = err.Error()
+		}
+		task := newTask(userID, input)
+		for field, msg := range validateNewTask(db, &task) {
+			errs[path+"."+field] = msg
+		}
+
+		checklist := make([]string, 0, len(t.Checklist))
+		for i, text := range t.Checklist {
+			text = strings.TrimSpace(substituteVariables(text, vars))
+			if err := validateChecklistText(text); err != nil {
+				errs[fmt.Sprintf("%s.checklist[%d]", path, i)] = err.Error()
+			}
+			checklist = append(checklist, text)
+		}
+
+		plan = append(plan, plannedTask{task: task, checklist: checklist, parent: parent})
+		self := len(plan) - 1
+		for i, sub := range t.Subtasks {
+			add(sub, fmt.Sprintf("%s.subtasks[%d]", path, i), self)
+		}
+	}
+	add(tmpl.Task, "task", -1)
+
+	if tmpl.ProjectID != nil {
+		if _, err := findUserProject(db, userID, *tmpl.ProjectID); err != nil {
+			errs["project_id"] = "Şablonun projesi bulunamadı"
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return plan, nil
+}
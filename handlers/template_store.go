@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+var errTemplateNotFound = errors.New("template not found")
+
+// userTemplates lists the user's templates
+func userTemplates(db *gorm.DB, userID uint) ([]models.TaskTemplate, error) {
+	templates := []models.TaskTemplate{}
+	if db != nil {
+		err := db.Where("user_id = ?", userID).Order("id").Find(&templates).Error
+		return templates, err
+	}
+
+	// In-memory mode (fallback)
+	for _, t := range models.TaskTemplates {
+		if t.UserID == userID {
+			templates = append(templates, t)
+		}
+	}
+	return templates, nil
+}
+
+// findUserTemplate loads a template owned by the user
+func findUserTemplate(db *gorm.DB, userID, id uint) (*models.TaskTemplate, error) {
+	if db != nil {
+		var tmpl models.TaskTemplate
+		if err := db.Where("id = ? AND user_id = ?", id, userID).First(&tmpl).Error; err != nil {
+			return nil, errTemplateNotFound
+		}
+		return &tmpl, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.TaskTemplates {
+		if models.TaskTemplates[i].ID == id && models.TaskTemplates[i].UserID == userID {
+			return &models.TaskTemplates[i], nil
+		}
+	}
+	return nil, errTemplateNotFound
+}
+
+// createTemplate stores a new template
+func createTemplate(db *gorm.DB, tmpl *models.TaskTemplate) error {
+	if db != nil {
+		return db.Create(tmpl).Error
+	}
+
+	// In-memory mode (fallback)
+	var max uint
+	for _, t := range models.TaskTemplates {
+		if t.ID > max {
+			max = t.ID
+		}
+	}
+	tmpl.ID = max + 1
+	tmpl.CreatedAt = time.Now()
+	tmpl.UpdatedAt = tmpl.CreatedAt
+	models.TaskTemplates = append(models.TaskTemplates, *tmpl)
+	return nil
+}
+
+// deleteTemplate removes the template; tasks created from it are kept
+func deleteTemplate(db *gorm.DB, tmpl *models.TaskTemplate) error {
+	if db != nil {
+		return db.Delete(tmpl).Error
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.TaskTemplates {
+		if models.TaskTemplates[i].ID == tmpl.ID {
+			models.TaskTemplates = append(models.TaskTemplates[:i], models.TaskTemplates[i+1:]...)
+			return nil
+		}
+	}
+	return errTemplateNotFound
+}
+
+// plannedTask is a task about to be created from a template. parent is the index
+// of its parent in the plan, or -1 for the root task.
+type plannedTask struct {
+	task      models.Task
+	checklist []string
+	parent    int
+}
+
+// instantiateTemplate creates the planned tasks with their checklists, parents
+// before children, all in one transaction. It returns the created tasks in plan order.
+func instantiateTemplate(db *gorm.DB, actorID uint, plan []plannedTask) ([]models.Task, error) {
+	create := func(tx *gorm.DB) ([]models.Task, error) {
+		created := make([]models.Task, len(plan))
+		for i, p := range plan {
+			task := p.task
+			if p.parent >= 0 {
+				task.ParentID = &created[p.parent].ID
+			}
+			if err := createTask(tx, actorID, &task); err != nil {
+				return nil, err
+			}
+			for _, text := range p.checklist {
+				item := models.ChecklistItem{TaskID: task.ID, Text: text}
+				if err := addChecklistItem(tx, actorID, &item); err != nil {
+					return nil, err
+				}
+			}
+			created[i] = task
+		}
+		return created, nil
+	}
+
+	if db != nil {
+		var created []models.Task
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			created, err = create(tx)
+			return err
+		})
+		return created, err
+	}
+
+	// In-memory mode (fallback): the plan is validated before anything is written,
+	// so a snapshot is enough to undo a failure
+	tasks := append([]models.Task(nil), models.Tasks...)
+	activities := append([]models.TaskActivity(nil), models.TaskActivities...)
+	items := append([]models.ChecklistItem(nil), models.ChecklistItems...)
+	created, err := create(nil)
+	if err != nil {
+		models.Tasks, models.TaskActivities, models.ChecklistItems = tasks, activities, items
+	}
+	return created, err
+}
@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	timeReportByTask = "task"
+	timeReportByDay  = "day"
+
+	maxTimeReportDays = 366
+)
+
+// TimerStartRequest zamanlayıcı başlatma isteği modeli
+type TimerStartRequest struct {
+	Note string `json:"note" example:"Müşteri toplantısı"`
+}
+
+// TimeEntryCreateRequest elle zaman kaydı ekleme isteği modeli.
+// ended_at ya da duration_seconds gönderilmelidir.
+type TimeEntryCreateRequest struct {
+	StartedAt       time.Time  `json:"started_at" example:"2025-08-25T09:00:00Z"`
+	EndedAt         *time.Time `json:"ended_at,omitempty" example:"2025-08-25T10:30:00Z"`
+	DurationSeconds int64      `json:"duration_seconds,omitempty" example:"5400"`
+	Note            string     `json:"note" example:"Analiz"`
+}
+
+// TimeReportGroup raporda bir görev ya da günün toplamı
+type TimeReportGroup struct {
+	Key          string `json:"key" example:"2025-08-25"` // Görev ID ya da YYYY-MM-DD
+	TaskID       uint   `json:"task_id,omitempty"`
+	TaskTitle    string `json:"task_title,omitempty"`
+	TotalSeconds int64  `json:"total_seconds" example:"5400"`
+	Entries      int    `json:"entries" example:"2"`
+}
+
+// TimeReport zaman kayıtları raporu
+type TimeReport struct {
+	From         time.Time         `json:"from"`
+	To           time.Time         `json:"to"`
+	GroupBy      string            `json:"group_by" enums:"task,day"`
+	TotalSeconds int64             `json:"total_seconds"`
+	Groups       []TimeReportGroup `json:"groups"`
+}
+
+// TimerStartHandler görev için zamanlayıcı başlatır
+// @ID TimerStartHandler
+// @Summary Zamanlayıcı başlat
+// @Description Görev için zamanlayıcı başlatır. Kullanıcının aynı anda yalnızca bir çalışan zamanlayıcısı olabilir.
+// @Tags Time Tracking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param request body TimerStartRequest false "Not"
+// @Success 201 {object} models.TimeEntry
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Router /tasks/{id}/timer/start [post]
+func TimerStartHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	var input TimerStartRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+		}
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+
+	entry, err := startTimer(taskDB(), userID, task.ID, input.Note)
+	if err != nil {
+		if errors.Is(err, errTimerRunning) {
+			running, _ := runningTimeEntry(taskDB(), userID)
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":      "Zaten çalışan bir zamanlayıcı var",
+				"time_entry": running,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Zamanlayıcı başlatılamadı"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(entry)
+}
+
+// TimerStopHandler çalışan zamanlayıcıyı durdurur
+// @ID TimerStopHandler
+// @Summary Zamanlayıcıyı durdur
+// @Description Kullanıcının çalışan zamanlayıcısını durdurur ve süresini kaydeder
+// @Tags Time Tracking
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.TimeEntry
+// @Failure 404 {object} map[string]string
+// @Router /timer/stop [post]
+func TimerStopHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	entry, err := stopTimer(taskDB(), userID)
+	if err != nil {
+		if errors.Is(err, errNoRunningTimer) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Çalışan zamanlayıcı yok"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Zamanlayıcı durdurulamadı"})
+	}
+	return c.JSON(entry)
+}
+
+// TimerHandler çalışan zamanlayıcıyı döner
+// @ID TimerHandler
+// @Summary Çalışan zamanlayıcı
+// @Description Kullanıcının çalışan zamanlayıcısını döner
+// @Tags Time Tracking
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.TimeEntry
+// @Failure 404 {object} map[string]string
+// @Router /timer [get]
+func TimerHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	entry, err := runningTimeEntry(taskDB(), userID)
+	if err != nil {
+		if errors.Is(err, errNoRunningTimer) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Çalışan zamanlayıcı yok"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Zamanlayıcı alınamadı"})
+	}
+	return c.JSON(entry)
+}
+
+// TaskTimeEntriesHandler görevin zaman kayıtlarını listeler
+// @ID TaskTimeEntriesHandler
+// @Summary Görev zaman kayıtları
+// @Description Görevin tüm zaman kayıtlarını eskiden yeniye döner
+// @Tags Time Tracking
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Success 200 {array} models.TimeEntry
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/time-entries [get]
+func TaskTimeEntriesHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+
+	entries, err := userTimeEntries(taskDB(), userID, &task.ID, time.Time{}, time.Time{})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Zaman kayıtları alınamadı"})
+	}
+	return c.JSON(entries)
+}
+
+// TimeEntryCreateHandler göreve elle zaman kaydı ekler
+// @ID TimeEntryCreateHandler
+// @Summary Zaman kaydı ekle
+// @Description Göreve başlangıç ile bitiş ya da süre vererek zaman kaydı ekler
+// @Tags Time Tracking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Param request body TimeEntryCreateRequest true "Zaman kaydı"
+// @Success 201 {object} models.TimeEntry
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Router /tasks/{id}/time-entries [post]
+func TimeEntryCreateHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	var input TimeEntryCreateRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+
+	entry, errs := newManualTimeEntry(userID, input)
+	if len(errs) > 0 {
+		return validationFailed(c, errs)
+	}
+
+	task, err := findUserTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Görev bulunamadı veya yetkiniz yok"})
+	}
+	entry.TaskID = task.ID
+
+	if err := createTimeEntry(taskDB(), &entry); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Zaman kaydı eklenemedi"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(entry)
+}
+
+// TimeEntryDeleteHandler zaman kaydını siler
+// @ID TimeEntryDeleteHandler
+// @Summary Zaman kaydını sil
+// @Tags Time Tracking
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Zaman kaydı ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /time-entries/{id} [delete]
+func TimeEntryDeleteHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz zaman kaydı ID"})
+	}
+
+	if err := deleteTimeEntry(taskDB(), userID, uint(id)); err != nil {
+		if errors.Is(err, errTimeEntryNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Zaman kaydı bulunamadı"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Zaman kaydı silinemedi"})
+	}
+	return c.JSON(fiber.Map{"message": "Zaman kaydı silindi"})
+}
+
+// TimeReportHandler zaman kayıtlarını görev ya da güne göre toplar
+// @ID TimeReportHandler
+// @Summary Zaman raporu
+// @Description Kullanıcının verilen aralıkta başlayan zaman kayıtlarını görev ya da güne (UTC) göre toplar. Tarihler YYYY-MM-DD (bitiş günü dahil) ya da RFC 3339 olabilir; varsayılan son 7 gündür. Çalışan zamanlayıcı şu ana kadar sayılır.
+// @Tags Time Tracking
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Başlangıç" example(2025-08-01)
+// @Param to query string false "Bitiş" example(2025-08-31)
+// @Param group_by query string false "Gruplama" Enums(task, day) default(task)
+// @Success 200 {object} TimeReport
+// @Failure 400 {object} map[string]string
+// @Router /time-entries [get]
+func TimeReportHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	groupBy := c.Query("group_by", timeReportByTask)
+	if groupBy != timeReportByTask && groupBy != timeReportByDay {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "group_by task veya day olmalı"})
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to, err := parseReportTime(c.Query("to"), true, today.AddDate(0, 0, 1))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz bitiş tarihi"})
+	}
+	from, err := parseReportTime(c.Query("from"), false, to.AddDate(0, 0, -7))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz başlangıç tarihi"})
+	}
+	if !from.Before(to) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Başlangıç bitişten önce olmalı"})
+	}
+	if to.Sub(from) > maxTimeReportDays*24*time.Hour {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Aralık en fazla %d gün olabilir", maxTimeReportDays)})
+	}
+
+	entries, err := userTimeEntries(taskDB(), userID, nil, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Zaman kayıtları alınamadı"})
+	}
+
+	report := TimeReport{From: from, To: to, GroupBy: groupBy, Groups: []TimeReportGroup{}}
+	now := time.Now()
+	index := make(map[string]int)
+	for i := range entries {
+		e := &entries[i]
+		key := e.StartedAt.UTC().Format(customFieldDateLayout)
+		if groupBy == timeReportByTask {
+			key = strconv.FormatUint(uint64(e.TaskID), 10)
+		}
+		pos, ok := index[key]
+		if !ok {
+			pos = len(report.Groups)
+			index[key] = pos
+			group := TimeReportGroup{Key: key}
+			if groupBy == timeReportByTask {
+				group.TaskID = e.TaskID
+			}
+			report.Groups = append(report.Groups, group)
+		}
+		seconds := e.SecondsAt(now)
+		report.Groups[pos].TotalSeconds += seconds
+		report.Groups[pos].Entries++
+		report.TotalSeconds += seconds
+	}
+
+	if groupBy == timeReportByTask {
+		titles := taskTitles(userID, report.Groups)
+		for i := range report.Groups {
+			report.Groups[i].TaskTitle = titles[report.Groups[i].TaskID]
+		}
+		sort.SliceStable(report.Groups, func(i, j int) bool { return report.Groups[i].TaskID < report.Groups[j].TaskID })
+	}
+	return c.JSON(report)
+}
+
+// newManualTimeEntry builds a finished entry from a manual entry request
+func newManualTimeEntry(userID uint, input TimeEntryCreateRequest) (models.TimeEntry, fieldErrors) {
+	entry := models.TimeEntry{UserID: userID, StartedAt: input.StartedAt, Note: input.Note}
+	errs := fieldErrors{}
+	if input.StartedAt.IsZero() {
+		errs["started_at"] = "Başlangıç zamanı zorunlu"
+		return entry, errs
+	}
+
+	switch {
+	case input.EndedAt != nil && input.DurationSeconds != 0:
+		errs["ended_at"] = "ended_at ile duration_seconds birlikte gönderilemez"
+	case input.EndedAt != nil:
+		if !input.EndedAt.After(input.StartedAt) {
+			errs["ended_at"] = "Bitiş başlangıçtan sonra olmalı"
+		}
+		entry.EndedAt = input.EndedAt
+		entry.DurationSeconds = int64(input.EndedAt.Sub(input.StartedAt).Seconds())
+	case input.DurationSeconds > 0:
+		end := input.StartedAt.Add(time.Duration(input.DurationSeconds) * time.Second)
+		entry.EndedAt = &end
+		entry.DurationSeconds = input.DurationSeconds
+	default:
+		errs["duration_seconds"] = "ended_at ya da pozitif duration_seconds zorunlu"
+	}
+	return entry, errs
+}
+
+// parseReportTime reads a report bound as a date or an RFC 3339 timestamp.
+// A date used as the end of the range includes that whole day.
+func parseReportTime(raw string, end bool, fallback time.Time) (time.Time, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	if t, err := time.Parse(customFieldDateLayout, raw); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// taskTitles looks up the titles of the grouped tasks, including trashed ones
+func taskTitles(userID uint, groups []TimeReportGroup) map[uint]string {
+	ids := make([]uint, 0, len(groups))
+	for _, g := range groups {
+		ids = append(ids, g.TaskID)
+	}
+	titles := make(map[uint]string, len(ids))
+
+	if db := taskDB(); db != nil {
+		var tasks []models.Task
+		db.Unscoped().Select("id", "title").Where("user_id = ? AND id IN ?", userID, ids).Find(&tasks)
+		for _, t := range tasks {
+			titles[t.ID] = t.Title
+		}
+		return titles
+	}
+
+	// In-memory mode (fallback)
+	for _, t := range models.Tasks {
+		if t.UserID == userID {
+			titles[t.ID] = t.Title
+		}
+	}
+	return titles
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	errTimerRunning      = errors.New("a timer is already running")
+	errNoRunningTimer    = errors.New("no running timer")
+	errTimeEntryNotFound = errors.New("time entry not found")
+)
+
+// runningTimeEntry returns the user's running timer, or errNoRunningTimer
+func runningTimeEntry(db *gorm.DB, userID uint) (*models.TimeEntry, error) {
+	if db != nil {
+		var entry models.TimeEntry
+		err := db.Where("user_id = ? AND ended_at IS NULL", userID).First(&entry).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errNoRunningTimer
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &entry, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.TimeEntries {
+		if models.TimeEntries[i].UserID == userID && models.TimeEntries[i].Running() {
+			return &models.TimeEntries[i], nil
+		}
+	}
+	return nil, errNoRunningTimer
+}
+
+// startTimer starts a timer on the task. Starts are serialized per user and a
+// partial unique index backs up the one-running-timer rule.
+func startTimer(db *gorm.DB, userID, taskID uint, note string) (*models.TimeEntry, error) {
+	entry := &models.TimeEntry{UserID: userID, TaskID: taskID, StartedAt: time.Now(), Note: note}
+
+	if db != nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('time_entries'), ?)", int32(userID)).Error; err != nil {
+				return err
+			}
+			if _, err := runningTimeEntry(tx, userID); err == nil {
+				return errTimerRunning
+			} else if !errors.Is(err, errNoRunningTimer) {
+				return err
+			}
+			return tx.Create(entry).Error
+		})
+		if err != nil {
+			return nil, err
+		}
+		return entry, nil
+	}
+
+	// In-memory mode (fallback)
+	if _, err := runningTimeEntry(nil, userID); err == nil {
+		return nil, errTimerRunning
+	}
+	createTimeEntry(nil, entry)
+	return entry, nil
+}
+
+// stopTimer stops the user's running timer and stores its duration
+func stopTimer(db *gorm.DB, userID uint) (*models.TimeEntry, error) {
+	entry, err := runningTimeEntry(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if db != nil {
+		// The guard on ended_at keeps a concurrent stop from overwriting the first one
+		result := db.Model(entry).Where("ended_at IS NULL").Updates(map[string]interface{}{
+			"ended_at":         now,
+			"duration_seconds": int64(now.Sub(entry.StartedAt).Seconds()),
+		})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil, errNoRunningTimer
+		}
+		return entry, db.First(entry, entry.ID).Error
+	}
+
+	// In-memory mode (fallback)
+	entry.EndedAt = &now
+	entry.DurationSeconds = int64(now.Sub(entry.StartedAt).Seconds())
+	entry.UpdatedAt = now
+	return entry, nil
+}
+
+// stopTaskTimers stops timers still running on the task when it is purged in
+// in-memory mode; database.PurgeTasks does it in the database. The entries are kept.
+func stopTaskTimers(taskID uint) {
+	now := time.Now()
+	for i := range models.TimeEntries {
+		e := &models.TimeEntries[i]
+		if e.TaskID == taskID && e.Running() {
+			e.EndedAt = &now
+			e.DurationSeconds = int64(now.Sub(e.StartedAt).Seconds())
+			e.UpdatedAt = now
+		}
+	}
+}
+
+// createTimeEntry stores a time entry
+func createTimeEntry(db *gorm.DB, entry *models.TimeEntry) error {
+	if db != nil {
+		return db.Create(entry).Error
+	}
+
+	// In-memory mode (fallback)
+	var max uint
+	for _, e := range models.TimeEntries {
+		if e.ID > max {
+			max = e.ID
+		}
+	}
+	now := time.Now()
+	entry.ID = max + 1
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	models.TimeEntries = append(models.TimeEntries, *entry)
+	return nil
+}
+
+// deleteTimeEntry removes one of the user's time entries
+func deleteTimeEntry(db *gorm.DB, userID, id uint) error {
+	if db != nil {
+		result := db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.TimeEntry{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errTimeEntryNotFound
+		}
+		return nil
+	}
+
+	// In-memory mode (fallback)
+	for i, e := range models.TimeEntries {
+		if e.ID == id && e.UserID == userID {
+			models.TimeEntries = append(models.TimeEntries[:i], models.TimeEntries[i+1:]...)
+			return nil
+		}
+	}
+	return errTimeEntryNotFound
+}
+
+// userTimeEntries returns the user's entries started in [from, to), optionally for one task,
+// oldest first. A zero from or to leaves that side of the range open.
+func userTimeEntries(db *gorm.DB, userID uint, taskID *uint, from, to time.Time) ([]models.TimeEntry, error) {
+	entries := []models.TimeEntry{}
+	if db != nil {
+		query := db.Where("user_id = ?", userID)
+		if !from.IsZero() {
+			query = query.Where("started_at >= ?", from)
+		}
+		if !to.IsZero() {
+			query = query.Where("started_at < ?", to)
+		}
+		if taskID != nil {
+			query = query.Where("task_id = ?", *taskID)
+		}
+		err := query.Order("started_at, id").Find(&entries).Error
+		return entries, err
+	}
+
+	// In-memory mode (fallback)
+	for _, e := range models.TimeEntries {
+		if e.UserID != userID || (!from.IsZero() && e.StartedAt.Before(from)) || (!to.IsZero() && !e.StartedAt.Before(to)) {
+			continue
+		}
+		if taskID != nil && e.TaskID != *taskID {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].StartedAt.Before(entries[j].StartedAt) })
+	return entries, nil
+}
+
+// setTrackedTime fills in the computed TrackedSeconds field of the tasks;
+// a running timer counts up to now
+func setTrackedTime(db *gorm.DB, tasks ...*models.Task) {
+	ids := make([]uint, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+
+	totals := make(map[uint]int64, len(ids))
+	now := time.Now()
+	if db != nil {
+		var rows []struct {
+			TaskID uint
+			Total  int64
+		}
+		err := db.Model(&models.TimeEntry{}).
+			Select("task_id, SUM(CASE WHEN ended_at IS NULL THEN EXTRACT(EPOCH FROM (? - started_at))::bigint ELSE duration_seconds END) AS total", now).
+			Where("task_id IN ?", ids).
+			Group("task_id").
+			Scan(&rows).Error
+		if err != nil {
+			return
+		}
+		for _, r := range rows {
+			totals[r.TaskID] = r.Total
+		}
+	} else {
+		// In-memory mode (fallback)
+		wanted := make(map[uint]bool, len(ids))
+		for _, id := range ids {
+			wanted[id] = true
+		}
+		for i := range models.TimeEntries {
+			if e := &models.TimeEntries[i]; wanted[e.TaskID] {
+				totals[e.TaskID] += e.SecondsAt(now)
+			}
+		}
+	}
+
+	for _, t := range tasks {
+		t.TrackedSeconds = totals[t.ID]
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RefreshTokenRequest token yenileme isteği modeli
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"4q2Zb0cJ8wT1n0h3PqVx7kYl9mRfA2sDgHjKlZxCvBn"`
+}
+
+// AuthUser token yanıtındaki kullanıcı bilgisi
+type AuthUser struct {
+	ID       uint   `json:"id" example:"1"`
+	Username string `json:"username" example:"hakan"`
+	Email    string `json:"email" example:"hakan@example.com"`
+	Role     string `json:"role" example:"user"`
+}
+
+// TokenResponse giriş ve token yenileme yanıtı
+type TokenResponse struct {
+	Token        string   `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // access_token ile aynı; eski istemciler için
+	AccessToken  string   `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string   `json:"refresh_token" example:"4q2Zb0cJ8wT1n0h3PqVx7kYl9mRfA2sDgHjKlZxCvBn"`
+	TokenType    string   `json:"token_type" example:"Bearer"`
+	ExpiresIn    int      `json:"expires_in" example:"900"` // Access token ömrü (saniye)
+	User         AuthUser `json:"user"`
+}
+
+func newTokenResponse(user models.User, access, refresh string) *TokenResponse {
+	return &TokenResponse{
+		Token:        access,
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(auth.AccessTokenTTL.Seconds()),
+		User: AuthUser{
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     user.Role,
+		},
+	}
+}
+
+// RefreshTokenHandler refresh token karşılığında yeni bir token çifti verir
+// @Summary Token yenile
+// @Description Refresh token'ı tek kullanımlık olarak tüketir, yeni bir 15 dakikalık access token ve yeni bir refresh token döner. Daha önce kullanılmış bir refresh token tekrar gönderilirse aynı girişten türeyen tüm refresh token'lar iptal edilir ve yeniden giriş gerekir.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /token/refresh [post]
+// @ID RefreshTokenHandler
+func RefreshTokenHandler(c *fiber.Ctx) error {
+	var input RefreshTokenRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	if input.RefreshToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Refresh token zorunlu"})
+	}
+
+	db := taskDB()
+	used, next, err := rotateRefreshToken(db, input.RefreshToken)
+	switch {
+	case errors.Is(err, errRefreshTokenReused):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Refresh token daha önce kullanılmış, tüm oturum iptal edildi"})
+	case errors.Is(err, errRefreshTokenInvalid):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Geçersiz veya süresi dolmuş refresh token"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Token yenilenemedi"})
+	}
+
+	user, err := findUserByID(db, used.UserID)
+	if err != nil {
+		revokeTokenFamily(db, used.FamilyID)
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Geçersiz veya süresi dolmuş refresh token"})
+	}
+	access, err := issueAccessToken(db, *user)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Token oluşturulamadı"})
+	}
+	return c.JSON(newTokenResponse(*user, access, next))
+}
+
+// JWKSHandler token imzalarını doğrulamak için açık anahtarları döner
+// @Summary JSON Web Key Set
+// @Description Access token'ları doğrulamak için kullanılabilecek açık anahtarlar. Token başlığındaki kid ile eşleşen anahtar seçilir. Rotasyonla değiştirilen anahtarlar imzaladıkları token'ların süresi dolana kadar listede kalır. HS256 kullanılıyorsa liste boştur.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} auth.JWKS
+// @Router /.well-known/jwks.json [get]
+// @ID JWKSHandler
+func JWKSHandler(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(auth.PublicKeys())
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+const refreshTokenTTL = 30 * 24 * time.Hour
+
+var (
+	errRefreshTokenInvalid = errors.New("refresh token invalid")
+	errRefreshTokenReused  = errors.New("refresh token reused")
+	errUserNotFound        = errors.New("user not found")
+)
+
+// randomToken returns a URL-safe random string with n bytes of entropy
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken is how opaque tokens are stored, so a leaked table can't be replayed
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// issueAccessToken signs a short-lived JWT for the user with a fresh jti, so the token
+// can be revoked on its own. The role's permissions are resolved now and carried in the
+// token, so a role change applies from the user's next token.
+func issueAccessToken(db *gorm.DB, user models.User) (string, error) {
+	jti, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+	role := user.Role
+	if role == "" {
+		role = auth.RoleUser
+	}
+	perms, err := rolePermissions(db, role)
+	if errors.Is(err, errRoleNotFound) {
+		perms = nil
+	} else if err != nil {
+		return "", err
+	}
+	claims := auth.Claims{
+		UserID:      user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		Role:        role,
+		Permissions: perms,
+	}
+	claims.ID = jti
+	return auth.NewAccessToken(claims)
+}
+
+// issueTokens creates an access token and a refresh token in the given family.
+// An empty familyID starts a new family, as a fresh login does.
+func issueTokens(db *gorm.DB, user models.User, familyID string) (*TokenResponse, error) {
+	access, err := issueAccessToken(db, user)
+	if err != nil {
+		return nil, err
+	}
+	if familyID == "" {
+		if familyID, err = randomToken(16); err != nil {
+			return nil, err
+		}
+	}
+	refresh, err := createRefreshToken(db, user.ID, familyID)
+	if err != nil {
+		return nil, err
+	}
+	return newTokenResponse(user, access, refresh), nil
+}
+
+// createRefreshToken stores a new refresh token and returns its raw value
+func createRefreshToken(db *gorm.DB, userID uint, familyID string) (string, error) {
+	raw, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	token := models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}
+	if db != nil {
+		if err := db.Create(&token).Error; err != nil {
+			return "", err
+		}
+		return raw, nil
+	}
+
+	// In-memory mode (fallback)
+	var max uint
+	for _, t := range models.RefreshTokens {
+		if t.ID > max {
+			max = t.ID
+		}
+	}
+	token.ID = max + 1
+	token.CreatedAt = time.Now()
+	models.RefreshTokens = append(models.RefreshTokens, token)
+	return raw, nil
+}
+
+// rotateRefreshToken consumes a refresh token and returns its successor in the same family.
+// Presenting a token that was already rotated means it leaked, so the whole family is
+// revoked and errRefreshTokenReused is returned.
+func rotateRefreshToken(db *gorm.DB, raw string) (*models.RefreshToken, string, error) {
+	hash := hashToken(raw)
+	now := time.Now()
+	if db != nil {
+		var token models.RefreshToken
+		if err := db.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+			return nil, "", errRefreshTokenInvalid
+		}
+		if token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+			return nil, "", errRefreshTokenInvalid
+		}
+		var next string
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// The conditional update lets exactly one of two concurrent refreshes win
+			res := tx.Model(&models.RefreshToken{}).
+				Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", token.ID).
+				Update("used_at", now)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return errRefreshTokenReused
+			}
+			var err error
+			next, err = createRefreshToken(tx, token.UserID, token.FamilyID)
+			return err
+		})
+		if errors.Is(err, errRefreshTokenReused) {
+			if err := revokeTokenFamily(db, token.FamilyID); err != nil {
+				return nil, "", err
+			}
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return &token, next, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.RefreshTokens {
+		token := &models.RefreshTokens[i]
+		if token.TokenHash != hash {
+			continue
+		}
+		if token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+			return nil, "", errRefreshTokenInvalid
+		}
+		if token.UsedAt != nil {
+			revokeTokenFamily(nil, token.FamilyID)
+			return nil, "", errRefreshTokenReused
+		}
+		token.UsedAt = &now
+		used := *token
+		next, err := createRefreshToken(nil, used.UserID, used.FamilyID)
+		if err != nil {
+			return nil, "", err
+		}
+		return &used, next, nil
+	}
+	return nil, "", errRefreshTokenInvalid
+}
+
+// revokeTokenFamily revokes every token of a family that is still active
+func revokeTokenFamily(db *gorm.DB, familyID string) error {
+	now := time.Now()
+	if db != nil {
+		return db.Model(&models.RefreshToken{}).
+			Where("family_id = ? AND revoked_at IS NULL", familyID).
+			Update("revoked_at", now).Error
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.RefreshTokens {
+		if models.RefreshTokens[i].FamilyID == familyID && models.RefreshTokens[i].RevokedAt == nil {
+			models.RefreshTokens[i].RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+// revokeRefreshToken revokes the family of one of the user's refresh tokens.
+// Unknown tokens are ignored, logging out should not reveal whether a token existed.
+func revokeRefreshToken(db *gorm.DB, userID uint, raw string) error {
+	hash := hashToken(raw)
+	if db != nil {
+		var token models.RefreshToken
+		if err := db.Where("token_hash = ? AND user_id = ?", hash, userID).First(&token).Error; err != nil {
+			return nil
+		}
+		return revokeTokenFamily(db, token.FamilyID)
+	}
+
+	// In-memory mode (fallback)
+	for _, t := range models.RefreshTokens {
+		if t.TokenHash == hash && t.UserID == userID {
+			return revokeTokenFamily(nil, t.FamilyID)
+		}
+	}
+	return nil
+}
+
+// revokeUserRefreshTokens revokes every active refresh token of the user
+func revokeUserRefreshTokens(db *gorm.DB, userID uint) error {
+	now := time.Now()
+	if db != nil {
+		return db.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.RefreshTokens {
+		if models.RefreshTokens[i].UserID == userID && models.RefreshTokens[i].RevokedAt == nil {
+			models.RefreshTokens[i].RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+// deleteExpiredRefreshTokens drops the user's tokens that can no longer be used
+func deleteExpiredRefreshTokens(db *gorm.DB, userID uint) error {
+	now := time.Now()
+	if db != nil {
+		return db.Where("user_id = ? AND expires_at <= ?", userID, now).Delete(&models.RefreshToken{}).Error
+	}
+
+	// In-memory mode (fallback)
+	kept := models.RefreshTokens[:0]
+	for _, t := range models.RefreshTokens {
+		if t.UserID != userID || now.Before(t.ExpiresAt) {
+			kept = append(kept, t)
+		}
+	}
+	models.RefreshTokens = kept
+	return nil
+}
+
+// findUserByID loads a user from the database or the in-memory store
+func findUserByID(db *gorm.DB, id uint) (*models.User, error) {
+	if db != nil {
+		var user models.User
+		if err := db.First(&user, id).Error; err != nil {
+			return nil, errUserNotFound
+		}
+		return &user, nil
+	}
+
+	// In-memory mode (fallback)
+	for i := range models.Users {
+		if models.Users[i].ID == id {
+			return &models.Users[i], nil
+		}
+	}
+	return nil, errUserNotFound
+}
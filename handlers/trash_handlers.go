@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"go_taskmanagement/database"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrashedTask çöp kutusundaki görev
+type TrashedTask struct {
+	models.Task
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at,omitempty"`
+}
+
+// TrashListHandler kullanıcının silinmiş görevlerini listeler
+// @ID TrashListHandler
+// @Summary Çöp kutusunu listele
+// @Description Kullanıcının silinmiş görevlerini en son silinen önce olacak şekilde döner. purge_at, görevin kalıcı olarak silineceği zamandır.
+// @Tags Tasks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} TrashedTask
+// @Router /tasks/trash [get]
+func TrashListHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	var trashed []models.Task
+	if db := taskDB(); db != nil {
+		db.Unscoped().Preload("User").
+			Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+			Order("deleted_at DESC").
+			Find(&trashed)
+	} else {
+		// In-memory mode (fallback)
+		for i := len(models.Tasks) - 1; i >= 0; i-- {
+			if models.Tasks[i].UserID == userID && models.Tasks[i].DeletedAt.Valid {
+				trashed = append(trashed, models.Tasks[i])
+			}
+		}
+	}
+
+	setComputedFields(taskDB(), taskPointers(trashed)...)
+	retention := database.TrashRetention()
+	out := make([]TrashedTask, 0, len(trashed))
+	for _, t := range trashed {
+		item := TrashedTask{Task: t, DeletedAt: t.DeletedAt.Time}
+		if retention > 0 {
+			purgeAt := t.DeletedAt.Time.Add(retention)
+			item.PurgeAt = &purgeAt
+		}
+		out = append(out, item)
+	}
+	return c.JSON(out)
+}
+
+// TaskRestoreHandler silinmiş görevi geri getirir
+// @ID TaskRestoreHandler
+// @Summary Görevi geri yükle
+// @Description Çöp kutusundaki görevi geri getirir
+// @Tags Tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Görev ID"
+// @Success 200 {object} models.Task
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/restore [post]
+func TaskRestoreHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	idStr := c.Params("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz görev ID"})
+	}
+
+	task, err := findTrashedTask(taskDB(), userID, uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Çöp kutusunda görev bulunamadı"})
+	}
+
+	if err := restoreTask(taskDB(), userID, task); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Görev geri yüklenemedi"})
+	}
+
+	setComputedFields(taskDB(), task)
+	c.Set(fiber.HeaderETag, taskETag(task))
+	return c.JSON(task)
+}
@@ -2,14 +2,13 @@ package handlers
 
 import (
 	"fmt"
-	"os"
 	"time"
 
+	"go_taskmanagement/auth"
 	"go_taskmanagement/database"
 	"go_taskmanagement/models"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -26,18 +25,9 @@ type LoginRequest struct {
 	Password string `json:"password" example:"1234"`
 }
 
-// Get JWT secret from environment variable
-func getJWTSecret() []byte {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		secret = "gizliAnahtar" // Fallback for development
-	}
-	return []byte(secret)
-}
-
 // RegisterHandler kullanıcı kaydı oluşturur
 // @Summary Kullanıcı kaydı
-// @Description Yeni kullanıcı oluşturur
+// @Description Yeni kullanıcı oluşturur ve email adresine 24 saat geçerli, imzalı bir doğrulama bağlantısı gönderir
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -76,6 +66,7 @@ func RegisterHandler(c *fiber.Ctx) error {
 			Username: input.Username,
 			Email:    input.Email,
 			Password: string(hash),
+			Role:     auth.RoleUser,
 		}
 
 		if err := database.DB.Create(&user).Error; err != nil {
@@ -83,13 +74,16 @@ func RegisterHandler(c *fiber.Ctx) error {
 			fmt.Printf("Database error creating user: %v\n", err)
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kullanıcı oluşturulamadı"})
 		}
+		sendVerificationEmail(&user)
 
 		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 			"message": "Kullanıcı başarıyla oluşturuldu",
 			"user": fiber.Map{
-				"id":       user.ID,
-				"username": user.Username,
-				"email":    user.Email,
+				"id":                user.ID,
+				"username":          user.Username,
+				"email":             user.Email,
+				"role":              user.Role,
+				"email_verified_at": user.EmailVerifiedAt,
 			},
 		})
 	}
@@ -114,29 +108,36 @@ func RegisterHandler(c *fiber.Ctx) error {
 		Username: input.Username,
 		Email:    input.Email,
 		Password: string(hash),
+		Role:     auth.RoleUser,
 	}
 
 	models.Users = append(models.Users, user)
+	sendVerificationEmail(&user)
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"message": "Kullanıcı başarıyla oluşturuldu",
 		"user": fiber.Map{
-			"id":       user.ID,
-			"username": user.Username,
-			"email":    user.Email,
+			"id":                user.ID,
+			"username":          user.Username,
+			"email":             user.Email,
+			"role":              user.Role,
+			"email_verified_at": user.EmailVerifiedAt,
 		},
 	})
 }
 
-// LoginHandler kullanıcı girişi yapar ve JWT token döner
+// LoginHandler kullanıcı girişi yapar, access ve refresh token döner
 // @Summary Kullanıcı girişi
-// @Description Email ve şifre ile giriş yapar
+// @Description Email ve şifre ile giriş yapar. 15 dakika geçerli bir access token ile POST /token/refresh üzerinden yenilenebilen tek kullanımlık bir refresh token döner. EMAIL_VERIFICATION=login ise email adresi doğrulanmamış kullanıcılar 403 alır. İki adımlı doğrulaması açık kullanıcılar token yerine mfa_required ve 5 dakika geçerli bir mfa_token alır; giriş POST /login/mfa ile tamamlanır. Başarısız girişler hesap ve IP başına sayılır: ilk ikisinden sonra her deneme öncesi bekleme süresi ikiye katlanır, LOGIN_MAX_FAILURES (varsayılan 5) hatadan sonra hesap LOGIN_LOCKOUT_DURATION (varsayılan 15 dakika) boyunca kilitlenir. Beklemesi gereken istekler Retry-After ile 429 alır.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param credentials body LoginRequest true "Email ve şifre"
-// @Success 200 {object} map[string]interface{}
+// @Success 200 {object} TokenResponse "2FA açıksa MFAChallengeResponse"
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /login [post]
 // @ID LoginHandler
 func LoginHandler(c *fiber.Ctx) error {
@@ -150,15 +151,18 @@ func LoginHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Email ve şifre zorunlu"})
 	}
 
+	db := taskDB()
+	attempt, throttled, err := beginLogin(c, db, input.Email)
+	if throttled {
+		return err
+	}
+
 	var user models.User
 	var found bool
 
 	// Database mode
 	if database.IsConnected && database.DB != nil {
-		if err := database.DB.Where("email = ?", input.Email).First(&user).Error; err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Email veya şifre yanlış"})
-		}
-		found = true
+		found = database.DB.Where("email = ?", input.Email).First(&user).Error == nil
 	} else {
 		// In-memory mode (fallback)
 		for _, u := range models.Users {
@@ -171,46 +175,108 @@ func LoginHandler(c *fiber.Ctx) error {
 	}
 
 	if !found {
+		attempt.failed(nil)
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Email veya şifre yanlış"})
 	}
 
 	// Check password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)); err != nil {
+		attempt.failed(&user)
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Email veya şifre yanlış"})
 	}
+	// With 2FA on, the account's failures are only forgotten once the code is right too
+	if user.TOTPEnabledAt != nil {
+		attempt.release()
+	} else {
+		attempt.succeeded()
+	}
 
-	// Create JWT token
-	claims := jwt.MapClaims{
-		"user_id":  user.ID,
-		"username": user.Username,
-		"email":    user.Email,
-		"exp":      time.Now().Add(time.Hour * 24).Unix(),
+	if emailVerificationMode() == verificationRequiredForLogin && user.EmailVerifiedAt == nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Giriş yapmak için email adresinizi doğrulayın"})
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(getJWTSecret())
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Token oluşturulamadı"})
+
+	// With 2FA on, the password only earns a challenge for POST /login/mfa
+	if user.TOTPEnabledAt != nil {
+		challenge, err := newMFAChallenge(user.ID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Token oluşturulamadı"})
+		}
+		return c.JSON(challenge)
 	}
 
-	return c.JSON(fiber.Map{
-		"token": tokenString,
-		"user": fiber.Map{
-			"id":       user.ID,
-			"username": user.Username,
-			"email":    user.Email,
-		},
-	})
+	// Access token is short-lived; the refresh token starts a new rotation family
+	return loginSucceeded(c, db, user)
+}
+
+// LogoutRequest çıkış isteği modeli
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty" example:"4q2Zb0cJ8wT1n0h3PqVx7kYl9mRfA2sDgHjKlZxCvBn"` // Verilirse bu girişin refresh token'ları da iptal edilir
 }
 
 // LogoutHandler kullanıcıyı çıkış yaptırır
 // @Summary Çıkış
-// @Description Kullanıcıyı çıkış yaptırır
+// @Description Kullanılan access token'ı süresi dolana kadar iptal eder. Gövdede refresh_token gönderilirse aynı girişten türeyen refresh token'lar da iptal edilir.
 // @Tags Auth
+// @Accept json
 // @Produce json
+// @Param request body LogoutRequest false "İptal edilecek refresh token"
 // @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
 // @Security BearerAuth
 // @Router /logout [post]
 // @ID LogoutHandler
 func LogoutHandler(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{"message": "Çıkış başarılı. Token client tarafından silinmeli."})
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	jti, _ := c.Locals("jti").(string)
+	expiresAt, _ := c.Locals("token_expires_at").(time.Time)
+	if jti == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Geçersiz token"})
+	}
+
+	var input LogoutRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+		}
+	}
+
+	if err := auth.Revocations.RevokeToken(jti, userID, expiresAt); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Çıkış yapılamadı"})
+	}
+	if input.RefreshToken != "" {
+		if err := revokeRefreshToken(taskDB(), userID, input.RefreshToken); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Çıkış yapılamadı"})
+		}
+	}
+	return c.JSON(fiber.Map{"message": "Çıkış başarılı"})
+}
+
+// LogoutAllHandler kullanıcının tüm oturumlarını kapatır
+// @Summary Tüm cihazlardan çıkış
+// @Description Kullanıcıya şimdiye kadar verilmiş tüm access token'ları ve refresh token'ları iptal eder; kullanıcı her cihazda yeniden giriş yapmalıdır.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /logout/all [post]
+// @ID LogoutAllHandler
+func LogoutAllHandler(c *fiber.Ctx) error {
+	uid := c.Locals("user_id")
+	userID, ok := uid.(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+
+	if err := revokeUserRefreshTokens(taskDB(), userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Çıkış yapılamadı"})
+	}
+	if err := auth.Revocations.RevokeUser(userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Çıkış yapılamadı"})
+	}
+	return c.JSON(fiber.Map{"message": "Tüm oturumlar kapatıldı"})
 }
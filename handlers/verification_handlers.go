@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/mail"
+	"go_taskmanagement/models"
+	"go_taskmanagement/ratelimit"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	resendVerificationPerIP    = ratelimit.New(10, time.Hour)
+	resendVerificationPerEmail = ratelimit.New(3, time.Hour)
+)
+
+// ResendVerificationRequest doğrulama e-postasını yeniden gönderme isteği modeli
+type ResendVerificationRequest struct {
+	Email string `json:"email" example:"hakan@example.com"`
+}
+
+// sendVerificationEmail mails the user a signed link to GET /verify-email
+func sendVerificationEmail(user *models.User) {
+	token := auth.NewEmailVerificationToken(user.ID, user.Email)
+	link := appURL("/verify-email", url.Values{"token": {token}})
+	sendMail(mail.Message{
+		To:      user.Email,
+		Subject: "Email adresinizi doğrulayın",
+		Body: "Merhaba " + user.Username + ",\n\n" +
+			"Email adresinizi doğrulamak için aşağıdaki bağlantıyı açın. Bağlantı 24 saat geçerlidir.\n\n" +
+			link + "\n\n" +
+			"Bu hesabı siz oluşturmadıysanız bu e-postayı yok sayabilirsiniz.\n",
+	})
+}
+
+// taskCreationBlocked answers 403 when EMAIL_VERIFICATION=tasks and the user has not
+// verified their address yet. Callers return the error when it reports true.
+func taskCreationBlocked(c *fiber.Ctx, userID uint) (bool, error) {
+	if emailVerificationMode() != verificationRequiredForTasks {
+		return false, nil
+	}
+	user, err := findUserByID(taskDB(), userID)
+	if err != nil {
+		return true, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Kullanıcı bilgisi alınamadı"})
+	}
+	if user.EmailVerifiedAt == nil {
+		return true, c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Görev oluşturmak için email adresinizi doğrulayın"})
+	}
+	return false, nil
+}
+
+// VerifyEmailHandler e-postadaki bağlantı ile email adresini doğrular
+// @ID VerifyEmailHandler
+// @Summary Email doğrula
+// @Description Kayıt e-postasındaki imzalı bağlantıyı doğrular ve email_verified_at alanını doldurur. Bağlantı 24 saat geçerlidir; email adresi değişmişse geçersiz olur. ADMIN_EMAILS içindeki bir adresi doğrulayan user rolündeki kullanıcı admin olur. Zaten doğrulanmış bir adres için de 200 döner.
+// @Tags Auth
+// @Produce json
+// @Param token query string true "E-postadaki doğrulama token'ı"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Router /verify-email [get]
+func VerifyEmailHandler(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Token zorunlu"})
+	}
+	userID, email, err := auth.VerifyEmailToken(token)
+	if errors.Is(err, auth.ErrTokenExpired) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Doğrulama bağlantısının süresi dolmuş, yeni bir bağlantı isteyin"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz doğrulama bağlantısı"})
+	}
+
+	user, already, err := markEmailVerified(taskDB(), userID, email)
+	switch {
+	case errors.Is(err, errUserNotFound), errors.Is(err, errEmailChanged):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz doğrulama bağlantısı"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Email doğrulanamadı"})
+	}
+
+	message := "Email adresi doğrulandı"
+	if already {
+		message = "Email adresi zaten doğrulanmış"
+	}
+	return c.JSON(fiber.Map{"message": message, "email_verified_at": user.EmailVerifiedAt})
+}
+
+// ResendVerificationHandler doğrulama e-postasını yeniden gönderir
+// @ID ResendVerificationHandler
+// @Summary Doğrulama e-postasını yeniden gönder
+// @Description Email kayıtlı ve henüz doğrulanmamışsa yeni bir doğrulama bağlantısı gönderir. Email'in kayıtlı olup olmadığı belli olmasın diye yanıt her zaman 202'dir. IP başına saatte 10 istek kabul edilir; aynı adrese saatte en fazla 3 e-posta gönderilir.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body ResendVerificationRequest true "Hesabın e-posta adresi"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} ValidationErrorResponse
+// @Failure 429 {object} map[string]string
+// @Router /verify-email/resend [post]
+func ResendVerificationHandler(c *fiber.Ctx) error {
+	var input ResendVerificationRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz veri"})
+	}
+	email := strings.TrimSpace(input.Email)
+	if email == "" {
+		return validationFailed(c, fieldErrors{"email": "Email zorunlu"})
+	}
+	if ok, retryAfter := resendVerificationPerIP.Allow(c.IP()); !ok {
+		return tooManyRequests(c, retryAfter)
+	}
+
+	accepted := func() error {
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"message": "Email kayıtlı ve doğrulanmamışsa doğrulama bağlantısı gönderildi"})
+	}
+	// Over the per-address limit the request is accepted but nothing is sent
+	if ok, _ := resendVerificationPerEmail.Allow(strings.ToLower(email)); !ok {
+		return accepted()
+	}
+
+	user, err := findUserByEmail(taskDB(), email)
+	if err == nil && user.EmailVerifiedAt == nil {
+		sendVerificationEmail(user)
+	}
+	return accepted()
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+// EMAIL_VERIFICATION values; anything else leaves unverified accounts fully usable
+const (
+	verificationRequiredForLogin = "login"
+	verificationRequiredForTasks = "tasks"
+)
+
+var errEmailChanged = errors.New("email changed since the link was sent")
+
+// emailVerificationMode reports what an unverified account may not do: "login",
+// "tasks" (creating tasks) or "" for nothing
+func emailVerificationMode() string {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv("EMAIL_VERIFICATION"))); mode {
+	case verificationRequiredForLogin, verificationRequiredForTasks:
+		return mode
+	}
+	return ""
+}
+
+// CheckEmailVerificationConfig refuses EMAIL_VERIFICATION=login or tasks without an
+// EMAIL_VERIFICATION_SECRET. Call it at startup: links signed with a per-process key
+// would break on every restart and lock unverified users out.
+func CheckEmailVerificationConfig() error {
+	if emailVerificationMode() == "" || auth.EmailVerificationSecretConfigured() {
+		return nil
+	}
+	return fmt.Errorf("EMAIL_VERIFICATION=%s needs an EMAIL_VERIFICATION_SECRET of at least %d characters",
+		emailVerificationMode(), auth.MinEmailVerificationSecret)
+}
+
+// markEmailVerified records that the user owns the address. A regular user whose
+// address is in ADMIN_EMAILS becomes an admin at the same time. It returns the user and
+// whether the address was already verified before.
+func markEmailVerified(db *gorm.DB, userID uint, email string) (*models.User, bool, error) {
+	user, err := findUserByID(db, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !strings.EqualFold(user.Email, email) {
+		return nil, false, errEmailChanged
+	}
+	if user.EmailVerifiedAt != nil {
+		return user, true, nil
+	}
+
+	now := time.Now()
+	role := user.Role
+	if role == auth.RoleUser && isAdminEmail(user.Email) {
+		role = auth.RoleAdmin
+	}
+	if db != nil {
+		// Only the first click sets the time, a concurrent one keeps it
+		res := db.Model(&models.User{}).Where("id = ? AND email_verified_at IS NULL", userID).
+			Updates(map[string]interface{}{"email_verified_at": now, "role": role})
+		if res.Error != nil {
+			return nil, false, res.Error
+		}
+		if res.RowsAffected == 0 {
+			user, err = findUserByID(db, userID)
+			return user, true, err
+		}
+	}
+
+	// In-memory mode updates the stored user through the pointer
+	user.EmailVerifiedAt = &now
+	user.Role = role
+	return user, false, nil
+}
@@ -5,12 +5,12 @@ import (
 	"log"
 	"os"
 
+	"go_taskmanagement/auth"
 	"go_taskmanagement/database"
 	"go_taskmanagement/handlers"
 	"go_taskmanagement/middleware"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
@@ -21,6 +21,9 @@ import (
 func NewApp() *fiber.App {
 	// Load environment variables for testing
 	godotenv.Load()
+	if err := handlers.CheckEmailVerificationConfig(); err != nil {
+		log.Fatal(err)
+	}
 
 	// Create Fiber app with custom config
 	app := fiber.New(fiber.Config{
@@ -37,25 +40,83 @@ func NewApp() *fiber.App {
 
 	// Middleware
 	app.Use(logger.New())
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
-	}))
+	app.Use(middleware.CORS())
 
 	// Public routes
 	app.Post("/register", handlers.RegisterHandler)
 	app.Post("/login", handlers.LoginHandler)
+	app.Post("/login/mfa", handlers.LoginMFAHandler)
+	app.Post("/token/refresh", handlers.RefreshTokenHandler)
+	app.Post("/password/forgot", handlers.ForgotPasswordHandler)
+	app.Post("/password/reset", handlers.ResetPasswordHandler)
+	app.Get("/verify-email", handlers.VerifyEmailHandler)
+	app.Post("/verify-email/resend", handlers.ResendVerificationHandler)
+	app.Get("/.well-known/jwks.json", handlers.JWKSHandler)
 	app.Get("/tasks/public", handlers.PublicTasksHandler)
 
-	// Protected routes with JWT middleware
+	// Protected routes with JWT middleware. API tokens only reach the routes that declare
+	// a scope; the rest (logout, token management, admin) need a login session.
 	protected := app.Group("/", middleware.AuthMiddleware)
-	protected.Get("/tasks", handlers.TasksListHandler)
-	protected.Post("/tasks", handlers.TaskCreateHandler)
-	protected.Get("/tasks/:id", handlers.TaskDetailHandler)
-	protected.Put("/tasks/:id", handlers.TaskUpdateHandler)
-	protected.Delete("/tasks/:id", handlers.TaskDeleteHandler)
+	tasksRead := middleware.RequireScope(auth.ScopeTasksRead)
+	tasksWrite := middleware.RequireScope(auth.ScopeTasksWrite)
+	protected.Get("/tasks", tasksRead, handlers.TasksListHandler)
+	protected.Post("/tasks", tasksWrite, handlers.TaskCreateHandler)
+	protected.Post("/tasks/bulk", tasksWrite, handlers.TaskBulkHandler)
+	protected.Get("/tasks/trash", tasksRead, handlers.TrashListHandler)
+	protected.Get("/tasks/:id", tasksRead, handlers.TaskDetailHandler)
+	protected.Put("/tasks/:id", tasksWrite, handlers.TaskUpdateHandler)
+	protected.Patch("/tasks/:id", tasksWrite, handlers.TaskPatchHandler)
+	protected.Delete("/tasks/:id", tasksWrite, handlers.TaskDeleteHandler)
+	protected.Post("/tasks/:id/restore", tasksWrite, handlers.TaskRestoreHandler)
+	protected.Post("/tasks/:id/reopen", tasksWrite, handlers.TaskReopenHandler)
+	protected.Post("/tasks/:id/move", tasksWrite, handlers.TaskMoveHandler)
+	protected.Get("/tasks/:id/activity", tasksRead, handlers.TaskActivityHandler)
+	protected.Get("/tasks/:id/checklist", tasksRead, handlers.TaskChecklistHandler)
+	protected.Post("/tasks/:id/checklist", tasksWrite, handlers.ChecklistItemCreateHandler)
+	protected.Patch("/tasks/:id/checklist/:item_id", tasksWrite, handlers.ChecklistItemUpdateHandler)
+	protected.Delete("/tasks/:id/checklist/:item_id", tasksWrite, handlers.ChecklistItemDeleteHandler)
+	protected.Post("/tasks/:id/checklist/:item_id/move", tasksWrite, handlers.ChecklistItemMoveHandler)
+	protected.Get("/tasks/:id/dependencies", tasksRead, handlers.TaskDependenciesHandler)
+	protected.Post("/tasks/:id/dependencies", tasksWrite, handlers.TaskDependencyAddHandler)
+	protected.Delete("/tasks/:id/dependencies/:blocker_id", tasksWrite, handlers.TaskDependencyRemoveHandler)
+	protected.Post("/tasks/:id/timer/start", tasksWrite, handlers.TimerStartHandler)
+	protected.Get("/tasks/:id/time-entries", tasksRead, handlers.TaskTimeEntriesHandler)
+	protected.Post("/tasks/:id/time-entries", tasksWrite, handlers.TimeEntryCreateHandler)
+	protected.Get("/timer", tasksRead, handlers.TimerHandler)
+	protected.Post("/timer/stop", tasksWrite, handlers.TimerStopHandler)
+	protected.Get("/time-entries", tasksRead, handlers.TimeReportHandler)
+	protected.Delete("/time-entries/:id", tasksWrite, handlers.TimeEntryDeleteHandler)
+	protected.Get("/templates", tasksRead, handlers.TemplatesListHandler)
+	protected.Post("/templates", tasksWrite, handlers.TemplateCreateHandler)
+	protected.Get("/templates/:id", tasksRead, handlers.TemplateDetailHandler)
+	protected.Delete("/templates/:id", tasksWrite, handlers.TemplateDeleteHandler)
+	protected.Post("/templates/:id/instantiate", tasksWrite, handlers.TemplateInstantiateHandler)
+	protected.Get("/burndown", tasksRead, handlers.BurndownHandler)
+	protected.Get("/projects", tasksRead, handlers.ProjectsListHandler)
+	protected.Post("/projects", tasksWrite, handlers.ProjectCreateHandler)
+	protected.Get("/projects/:id", tasksRead, handlers.ProjectDetailHandler)
+	protected.Put("/projects/:id/workflow", tasksWrite, handlers.ProjectWorkflowUpdateHandler)
+	protected.Get("/projects/:id/fields", tasksRead, handlers.CustomFieldsListHandler)
+	protected.Post("/projects/:id/fields", tasksWrite, handlers.CustomFieldCreateHandler)
+	protected.Delete("/projects/:id/fields/:key", tasksWrite, handlers.CustomFieldDeleteHandler)
 	protected.Post("/logout", handlers.LogoutHandler)
+	protected.Post("/logout/all", handlers.LogoutAllHandler)
+	protected.Get("/api-tokens", handlers.APITokensListHandler)
+	protected.Post("/api-tokens", handlers.APITokenCreateHandler)
+	protected.Delete("/api-tokens/:id", handlers.APITokenDeleteHandler)
+	protected.Get("/2fa", handlers.MFAStatusHandler)
+	protected.Post("/2fa/enroll", handlers.MFAEnrollHandler)
+	protected.Post("/2fa/confirm", handlers.MFAConfirmHandler)
+	protected.Post("/2fa/disable", handlers.MFADisableHandler)
+	protected.Post("/2fa/recovery-codes", handlers.MFARecoveryCodesHandler)
+	protected.Get("/admin/users", middleware.RequirePermission(auth.PermUsersRead), handlers.AdminUsersListHandler)
+	protected.Put("/admin/users/:id/role", middleware.RequirePermission(auth.PermUsersManage), handlers.AdminUserRoleHandler)
+	protected.Get("/admin/lockouts", middleware.RequirePermission(auth.PermUsersRead), handlers.AdminLockoutsListHandler)
+	protected.Delete("/admin/lockouts/:kind/:value", middleware.RequirePermission(auth.PermUsersManage), handlers.AdminLockoutDeleteHandler)
+	protected.Get("/admin/roles", middleware.RequirePermission(auth.PermRolesManage), handlers.AdminRolesListHandler)
+	protected.Post("/admin/roles", middleware.RequirePermission(auth.PermRolesManage), handlers.AdminRoleCreateHandler)
+	protected.Delete("/admin/roles/:name", middleware.RequirePermission(auth.PermRolesManage), handlers.AdminRoleDeleteHandler)
+	protected.Delete("/admin/public-tasks/:id", middleware.RequirePermission(auth.PermTasksModerate), handlers.AdminPublicTaskDeleteHandler)
 
 	return app
 }
@@ -0,0 +1,167 @@
+// Package lockout slows down and then stops password guessing. Failed logins are
+// counted per key, an account or a client IP: past a few free failures each one
+// doubles the wait before the next attempt, and after too many the key is locked for
+// a while. Every attempt is counted as a failure before it is checked and given back
+// when it succeeds, so concurrent attempts can't get past the limit. The counts live
+// behind Store, so they are shared between instances when a database is connected.
+package lockout
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_taskmanagement/models"
+)
+
+// Key kinds
+const (
+	KindAccount = "account"
+	KindIP      = "ip"
+)
+
+// Store keeps the failed login counts
+type Store interface {
+	// Get returns the state of the key; an unknown key has no failures
+	Get(key string) (models.LoginAttempt, error)
+	// Fail records a failure at now and returns the new state in one atomic step.
+	// Failures from before now-window, or from before a lock that has expired, are
+	// forgotten first.
+	Fail(key string, now time.Time, window time.Duration) (models.LoginAttempt, error)
+	// Release takes back one failure recorded by Fail
+	Release(key string) error
+	// Lock locks the key until the given time. It reports false when the key was already
+	// locked, so each lockout is reported once even with concurrent logins.
+	Lock(key string, now, until time.Time) (bool, error)
+	// Reset forgets the key
+	Reset(key string) error
+	// Locked lists the keys locked at now
+	Locked(now time.Time) ([]models.LoginAttempt, error)
+}
+
+// Policy says how failures of one kind of key are punished
+type Policy struct {
+	MaxFailures  int           // Failures before the key is locked; 0 never locks
+	FreeFailures int           // Failures allowed without a wait, for typos
+	Lockout      time.Duration // How long a lock lasts; failures older than this are forgotten
+	BaseDelay    time.Duration // Wait after the first failure past the free ones, doubled after each further one
+	MaxDelay     time.Duration // Upper bound of the wait
+}
+
+// AccountPolicy is the policy for accounts, configured with LOGIN_MAX_FAILURES (default 5),
+// LOGIN_LOCKOUT_DURATION (15m), LOGIN_DELAY_BASE (1s) and LOGIN_DELAY_MAX (30s). The
+// first two failures are free.
+func AccountPolicy() Policy {
+	return Policy{
+		MaxFailures:  envInt("LOGIN_MAX_FAILURES", 5),
+		FreeFailures: 2,
+		Lockout:      envDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		BaseDelay:    envDuration("LOGIN_DELAY_BASE", time.Second),
+		MaxDelay:     envDuration("LOGIN_DELAY_MAX", 30*time.Second),
+	}
+}
+
+// IPPolicy is the policy for client IPs. Many users may share an address, so it allows
+// LOGIN_MAX_FAILURES_PER_IP (default 20) failures and waits only after half of them;
+// the other settings are the account ones.
+func IPPolicy() Policy {
+	p := AccountPolicy()
+	p.MaxFailures = envInt("LOGIN_MAX_FAILURES_PER_IP", 20)
+	p.FreeFailures = p.MaxFailures / 2
+	return p
+}
+
+// Delay returns the wait after the given number of failures
+func (p Policy) Delay(failures int) time.Duration {
+	n := failures - p.FreeFailures
+	if n <= 0 || p.BaseDelay <= 0 {
+		return 0
+	}
+	d := p.BaseDelay
+	for i := 1; i < n && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// Guard applies a policy to the keys of a store
+type Guard struct {
+	Store  Store
+	Policy Policy
+}
+
+// Wait returns how long the key has to wait before its next attempt, and whether that
+// is because the key is locked rather than delayed
+func (g Guard) Wait(key string, now time.Time) (time.Duration, bool, error) {
+	a, err := g.Store.Get(key)
+	if err != nil || a.Failures == 0 {
+		return 0, false, err
+	}
+	if a.LockedUntil != nil {
+		if now.Before(*a.LockedUntil) {
+			return a.LockedUntil.Sub(now), true, nil
+		}
+		return 0, false, nil
+	}
+	if next := a.LastFailureAt.Add(g.Policy.Delay(a.Failures)); now.Before(next) {
+		return next.Sub(now), false, nil
+	}
+	return 0, false, nil
+}
+
+// Reserve counts an attempt as failed before it is checked. It reports false when
+// other attempts already used up the key's failures; the caller then refuses this one
+// unchecked and releases it.
+func (g Guard) Reserve(key string, now time.Time) (models.LoginAttempt, bool, error) {
+	a, err := g.Store.Fail(key, now, g.Policy.Lockout)
+	if err != nil {
+		return a, false, err
+	}
+	return a, g.Policy.MaxFailures <= 0 || a.Failures <= g.Policy.MaxFailures, nil
+}
+
+// Fail settles a reserved attempt that turned out wrong, locking the key once its
+// failures reach the limit. It reports true when this failure locked the key.
+func (g Guard) Fail(key string, a models.LoginAttempt, now time.Time) (models.LoginAttempt, bool, error) {
+	if g.Policy.MaxFailures <= 0 || a.Failures < g.Policy.MaxFailures {
+		return a, false, nil
+	}
+	until := now.Add(g.Policy.Lockout)
+	locked, err := g.Store.Lock(key, now, until)
+	if locked {
+		a.LockedUntil = &until
+	}
+	return a, locked, err
+}
+
+// Key returns the lockout key of an account email or a client IP
+func Key(kind, value string) string {
+	if kind == KindAccount {
+		value = strings.ToLower(strings.TrimSpace(value))
+	}
+	return kind + ":" + value
+}
+
+// SplitKey returns the kind and value of a key
+func SplitKey(key string) (kind, value string) {
+	kind, value, _ = strings.Cut(key, ":")
+	return kind, value
+}
+
+func envInt(key string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n >= 0 {
+		return n
+	}
+	return fallback
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d >= 0 {
+		return d
+	}
+	return fallback
+}
@@ -0,0 +1,125 @@
+package lockout
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go_taskmanagement/models"
+)
+
+// MemoryStore keeps the counts in process, for in-memory mode and tests
+type MemoryStore struct {
+	mu       sync.Mutex
+	attempts map[string]models.LoginAttempt
+	calls    int
+}
+
+// sweepEvery is how many failures pass between removals of forgotten keys
+const sweepEvery = 1024
+
+// NewMemoryStore returns an empty store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{attempts: make(map[string]models.LoginAttempt)}
+}
+
+// Get implements Store
+func (s *MemoryStore) Get(key string) (models.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.attempts[key]; ok {
+		return a, nil
+	}
+	return models.LoginAttempt{Key: key}, nil
+}
+
+// Fail implements Store
+func (s *MemoryStore) Fail(key string, now time.Time, window time.Duration) (models.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.calls%sweepEvery == 0 {
+		s.sweep(now, window)
+	}
+
+	a, ok := s.attempts[key]
+	if !ok || forgotten(a, now, window) {
+		a = models.LoginAttempt{Key: key}
+	}
+	a.Failures++
+	a.LastFailureAt = now
+	s.attempts[key] = a
+	return a, nil
+}
+
+// Release implements Store
+func (s *MemoryStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.attempts[key]
+	switch {
+	case !ok:
+	case a.Failures <= 1 && a.LockedUntil == nil:
+		delete(s.attempts, key)
+	default:
+		a.Failures--
+		s.attempts[key] = a
+	}
+	return nil
+}
+
+// Lock implements Store
+func (s *MemoryStore) Lock(key string, now, until time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.attempts[key]
+	if !ok {
+		a = models.LoginAttempt{Key: key, LastFailureAt: now}
+	}
+	if a.LockedUntil != nil && now.Before(*a.LockedUntil) {
+		return false, nil
+	}
+	a.LockedUntil = &until
+	s.attempts[key] = a
+	return true, nil
+}
+
+// Reset implements Store
+func (s *MemoryStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, key)
+	return nil
+}
+
+// Locked implements Store
+func (s *MemoryStore) Locked(now time.Time) ([]models.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	locked := []models.LoginAttempt{}
+	for _, a := range s.attempts {
+		if a.LockedUntil != nil && now.Before(*a.LockedUntil) {
+			locked = append(locked, a)
+		}
+	}
+	sort.Slice(locked, func(i, j int) bool { return locked[i].LockedUntil.Before(*locked[j].LockedUntil) })
+	return locked, nil
+}
+
+// sweep drops keys whose failures are forgotten. Callers hold s.mu.
+func (s *MemoryStore) sweep(now time.Time, window time.Duration) {
+	for key, a := range s.attempts {
+		if forgotten(a, now, window) {
+			delete(s.attempts, key)
+		}
+	}
+}
+
+// forgotten reports whether the failures of a no longer count: the last one is older
+// than the window, or the lock they caused has expired
+func forgotten(a models.LoginAttempt, now time.Time, window time.Duration) bool {
+	if a.LockedUntil != nil {
+		return !now.Before(*a.LockedUntil)
+	}
+	return !a.LastFailureAt.After(now.Add(-window))
+}
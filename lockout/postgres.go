@@ -0,0 +1,78 @@
+package lockout
+
+import (
+	"errors"
+	"time"
+
+	"go_taskmanagement/models"
+
+	"gorm.io/gorm"
+)
+
+// PostgresStore keeps the counts in the login_attempts table, shared by all instances
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresStore returns a store using db
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Get implements Store
+func (s *PostgresStore) Get(key string) (models.LoginAttempt, error) {
+	var a models.LoginAttempt
+	err := s.db.Where("key = ?", key).First(&a).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.LoginAttempt{Key: key}, nil
+	}
+	return a, err
+}
+
+// failSQL counts a failure in one statement, so concurrent failures are all counted.
+// It mirrors forgotten: an expired lock or an old last failure starts a new count.
+const failSQL = `
+INSERT INTO login_attempts (key, failures, last_failure_at) VALUES (@key, 1, @now)
+ON CONFLICT (key) DO UPDATE SET
+	failures = CASE
+		WHEN login_attempts.locked_until IS NOT NULL AND login_attempts.locked_until <= @now THEN 1
+		WHEN login_attempts.locked_until IS NULL AND login_attempts.last_failure_at <= @since THEN 1
+		ELSE login_attempts.failures + 1
+	END,
+	locked_until = CASE WHEN login_attempts.locked_until <= @now THEN NULL ELSE login_attempts.locked_until END,
+	last_failure_at = @now
+RETURNING key, failures, last_failure_at, locked_until`
+
+// Fail implements Store
+func (s *PostgresStore) Fail(key string, now time.Time, window time.Duration) (models.LoginAttempt, error) {
+	var a models.LoginAttempt
+	err := s.db.Raw(failSQL, map[string]interface{}{"key": key, "now": now, "since": now.Add(-window)}).Scan(&a).Error
+	return a, err
+}
+
+// Release implements Store
+func (s *PostgresStore) Release(key string) error {
+	return s.db.Model(&models.LoginAttempt{}).
+		Where("key = ? AND failures > 0", key).
+		Update("failures", gorm.Expr("failures - 1")).Error
+}
+
+// Lock implements Store
+func (s *PostgresStore) Lock(key string, now, until time.Time) (bool, error) {
+	res := s.db.Model(&models.LoginAttempt{}).
+		Where("key = ? AND (locked_until IS NULL OR locked_until <= ?)", key, now).
+		Update("locked_until", until)
+	return res.RowsAffected > 0, res.Error
+}
+
+// Reset implements Store
+func (s *PostgresStore) Reset(key string) error {
+	return s.db.Where("key = ?", key).Delete(&models.LoginAttempt{}).Error
+}
+
+// Locked implements Store
+func (s *PostgresStore) Locked(now time.Time) ([]models.LoginAttempt, error) {
+	locked := []models.LoginAttempt{}
+	err := s.db.Where("locked_until > ?", now).Order("locked_until").Find(&locked).Error
+	return locked, err
+}
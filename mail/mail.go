@@ -0,0 +1,130 @@
+// Package mail sends the emails of the account flows. The driver is chosen with
+// MAIL_DRIVER: "smtp" for a real server, "file" to append messages to MAIL_FILE,
+// or "stdout" (the default) for development and offline tests.
+package mail
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// SMTPMailer sends messages through an SMTP server, with PLAIN auth when a username is set
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// Send implements Mailer
+func (m *SMTPMailer) Send(msg Message) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+	return smtp.SendMail(net.JoinHostPort(m.Host, m.Port), auth, m.From, []string{msg.To}, format(m.From, msg))
+}
+
+// WriterMailer writes messages to a writer, such as stdout or a file
+type WriterMailer struct {
+	From string
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterMailer returns a mailer that writes every message to w
+func NewWriterMailer(w io.Writer, from string) *WriterMailer {
+	return &WriterMailer{w: w, From: from}
+}
+
+// Send implements Mailer
+func (m *WriterMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, err := fmt.Fprintf(m.w, "%s\r\n", format(m.From, msg))
+	return err
+}
+
+// format renders the message in RFC 5322 form
+func format(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultMailer Mailer
+)
+
+// Default returns the mailer configured by the MAIL_* and SMTP_* environment variables.
+// An unusable MAIL_FILE falls back to stdout.
+func Default() Mailer {
+	defaultOnce.Do(func() {
+		from := getEnv("MAIL_FROM", "no-reply@localhost")
+		switch os.Getenv("MAIL_DRIVER") {
+		case "smtp":
+			defaultMailer = &SMTPMailer{
+				Host:     getEnv("SMTP_HOST", "localhost"),
+				Port:     getEnv("SMTP_PORT", "587"),
+				Username: os.Getenv("SMTP_USERNAME"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+				From:     from,
+			}
+		case "file":
+			path := getEnv("MAIL_FILE", "mail.log")
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+			if err != nil {
+				log.Printf("Failed to open mail file %s, writing mails to stdout: %v", path, err)
+				defaultMailer = NewWriterMailer(os.Stdout, from)
+				return
+			}
+			defaultMailer = NewWriterMailer(f, from)
+		default:
+			defaultMailer = NewWriterMailer(os.Stdout, from)
+		}
+	})
+	return defaultMailer
+}
+
+// SetDefault replaces the mailer returned by Default
+func SetDefault(m Mailer) {
+	defaultOnce.Do(func() {})
+	defaultMailer = m
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -6,13 +6,13 @@ package main
 
 import (
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	swagger "github.com/gofiber/swagger"
 	"github.com/joho/godotenv"
 	_ "go_taskmanagement/docs"
 	"log"
 	"os"
 
+	"go_taskmanagement/auth"
 	"go_taskmanagement/database"
 	"go_taskmanagement/handlers"
 	"go_taskmanagement/middleware"
@@ -24,15 +24,23 @@ func main() {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	if err := handlers.CheckEmailVerificationConfig(); err != nil {
+		log.Fatal(err)
+	}
+
 	// Connect to database
 	database.Connect()
 	database.Migrate()
 	database.SeedTestData()
+	database.StartTrashPurger()
+	database.StartRankRebalancer()
+	database.StartTokenPurger()
+	auth.StartKeyRotation()
 
 	app := fiber.New()
 
 	// CORS middleware
-	app.Use(cors.New())
+	app.Use(middleware.CORS())
 
 	// Swagger UI endpoints
 	app.Get("/swagger/*", swagger.HandlerDefault)
@@ -40,15 +48,77 @@ func main() {
 	// Public endpoints
 	app.Post("/register", handlers.RegisterHandler)
 	app.Post("/login", handlers.LoginHandler)
+	app.Post("/login/mfa", handlers.LoginMFAHandler)
+	app.Post("/token/refresh", handlers.RefreshTokenHandler)
+	app.Post("/password/forgot", handlers.ForgotPasswordHandler)
+	app.Post("/password/reset", handlers.ResetPasswordHandler)
+	app.Get("/verify-email", handlers.VerifyEmailHandler)
+	app.Post("/verify-email/resend", handlers.ResendVerificationHandler)
+	app.Get("/.well-known/jwks.json", handlers.JWKSHandler)
 	app.Get("/tasks/public", handlers.PublicTasksHandler)
 
-	// Private endpoints with JWT auth
-	app.Get("/tasks", middleware.AuthMiddleware, handlers.TasksListHandler)
-	app.Post("/tasks", middleware.AuthMiddleware, handlers.TaskCreateHandler)
-	app.Get("/tasks/:id", middleware.AuthMiddleware, handlers.TaskDetailHandler)
-	app.Put("/tasks/:id", middleware.AuthMiddleware, handlers.TaskUpdateHandler)
-	app.Delete("/tasks/:id", middleware.AuthMiddleware, handlers.TaskDeleteHandler)
+	// Private endpoints with JWT auth. API tokens only reach the routes that declare a
+	// scope; the rest (logout, token management, admin) need a login session.
+	tasksRead := middleware.RequireScope(auth.ScopeTasksRead)
+	tasksWrite := middleware.RequireScope(auth.ScopeTasksWrite)
+	app.Get("/tasks", middleware.AuthMiddleware, tasksRead, handlers.TasksListHandler)
+	app.Post("/tasks", middleware.AuthMiddleware, tasksWrite, handlers.TaskCreateHandler)
+	app.Post("/tasks/bulk", middleware.AuthMiddleware, tasksWrite, handlers.TaskBulkHandler)
+	app.Get("/tasks/trash", middleware.AuthMiddleware, tasksRead, handlers.TrashListHandler)
+	app.Get("/tasks/:id", middleware.AuthMiddleware, tasksRead, handlers.TaskDetailHandler)
+	app.Put("/tasks/:id", middleware.AuthMiddleware, tasksWrite, handlers.TaskUpdateHandler)
+	app.Patch("/tasks/:id", middleware.AuthMiddleware, tasksWrite, handlers.TaskPatchHandler)
+	app.Delete("/tasks/:id", middleware.AuthMiddleware, tasksWrite, handlers.TaskDeleteHandler)
+	app.Post("/tasks/:id/restore", middleware.AuthMiddleware, tasksWrite, handlers.TaskRestoreHandler)
+	app.Post("/tasks/:id/reopen", middleware.AuthMiddleware, tasksWrite, handlers.TaskReopenHandler)
+	app.Post("/tasks/:id/move", middleware.AuthMiddleware, tasksWrite, handlers.TaskMoveHandler)
+	app.Get("/tasks/:id/activity", middleware.AuthMiddleware, tasksRead, handlers.TaskActivityHandler)
+	app.Get("/tasks/:id/checklist", middleware.AuthMiddleware, tasksRead, handlers.TaskChecklistHandler)
+	app.Post("/tasks/:id/checklist", middleware.AuthMiddleware, tasksWrite, handlers.ChecklistItemCreateHandler)
+	app.Patch("/tasks/:id/checklist/:item_id", middleware.AuthMiddleware, tasksWrite, handlers.ChecklistItemUpdateHandler)
+	app.Delete("/tasks/:id/checklist/:item_id", middleware.AuthMiddleware, tasksWrite, handlers.ChecklistItemDeleteHandler)
+	app.Post("/tasks/:id/checklist/:item_id/move", middleware.AuthMiddleware, tasksWrite, handlers.ChecklistItemMoveHandler)
+	app.Get("/tasks/:id/dependencies", middleware.AuthMiddleware, tasksRead, handlers.TaskDependenciesHandler)
+	app.Post("/tasks/:id/dependencies", middleware.AuthMiddleware, tasksWrite, handlers.TaskDependencyAddHandler)
+	app.Delete("/tasks/:id/dependencies/:blocker_id", middleware.AuthMiddleware, tasksWrite, handlers.TaskDependencyRemoveHandler)
+	app.Post("/tasks/:id/timer/start", middleware.AuthMiddleware, tasksWrite, handlers.TimerStartHandler)
+	app.Get("/tasks/:id/time-entries", middleware.AuthMiddleware, tasksRead, handlers.TaskTimeEntriesHandler)
+	app.Post("/tasks/:id/time-entries", middleware.AuthMiddleware, tasksWrite, handlers.TimeEntryCreateHandler)
+	app.Get("/timer", middleware.AuthMiddleware, tasksRead, handlers.TimerHandler)
+	app.Post("/timer/stop", middleware.AuthMiddleware, tasksWrite, handlers.TimerStopHandler)
+	app.Get("/time-entries", middleware.AuthMiddleware, tasksRead, handlers.TimeReportHandler)
+	app.Delete("/time-entries/:id", middleware.AuthMiddleware, tasksWrite, handlers.TimeEntryDeleteHandler)
+	app.Get("/templates", middleware.AuthMiddleware, tasksRead, handlers.TemplatesListHandler)
+	app.Post("/templates", middleware.AuthMiddleware, tasksWrite, handlers.TemplateCreateHandler)
+	app.Get("/templates/:id", middleware.AuthMiddleware, tasksRead, handlers.TemplateDetailHandler)
+	app.Delete("/templates/:id", middleware.AuthMiddleware, tasksWrite, handlers.TemplateDeleteHandler)
+	app.Post("/templates/:id/instantiate", middleware.AuthMiddleware, tasksWrite, handlers.TemplateInstantiateHandler)
+	app.Get("/burndown", middleware.AuthMiddleware, tasksRead, handlers.BurndownHandler)
+	app.Get("/projects", middleware.AuthMiddleware, tasksRead, handlers.ProjectsListHandler)
+	app.Post("/projects", middleware.AuthMiddleware, tasksWrite, handlers.ProjectCreateHandler)
+	app.Get("/projects/:id", middleware.AuthMiddleware, tasksRead, handlers.ProjectDetailHandler)
+	app.Put("/projects/:id/workflow", middleware.AuthMiddleware, tasksWrite, handlers.ProjectWorkflowUpdateHandler)
+	app.Get("/projects/:id/fields", middleware.AuthMiddleware, tasksRead, handlers.CustomFieldsListHandler)
+	app.Post("/projects/:id/fields", middleware.AuthMiddleware, tasksWrite, handlers.CustomFieldCreateHandler)
+	app.Delete("/projects/:id/fields/:key", middleware.AuthMiddleware, tasksWrite, handlers.CustomFieldDeleteHandler)
 	app.Post("/logout", middleware.AuthMiddleware, handlers.LogoutHandler)
+	app.Post("/logout/all", middleware.AuthMiddleware, handlers.LogoutAllHandler)
+	app.Get("/api-tokens", middleware.AuthMiddleware, handlers.APITokensListHandler)
+	app.Post("/api-tokens", middleware.AuthMiddleware, handlers.APITokenCreateHandler)
+	app.Delete("/api-tokens/:id", middleware.AuthMiddleware, handlers.APITokenDeleteHandler)
+	app.Get("/2fa", middleware.AuthMiddleware, handlers.MFAStatusHandler)
+	app.Post("/2fa/enroll", middleware.AuthMiddleware, handlers.MFAEnrollHandler)
+	app.Post("/2fa/confirm", middleware.AuthMiddleware, handlers.MFAConfirmHandler)
+	app.Post("/2fa/disable", middleware.AuthMiddleware, handlers.MFADisableHandler)
+	app.Post("/2fa/recovery-codes", middleware.AuthMiddleware, handlers.MFARecoveryCodesHandler)
+	app.Get("/admin/users", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermUsersRead), handlers.AdminUsersListHandler)
+	app.Put("/admin/users/:id/role", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermUsersManage), handlers.AdminUserRoleHandler)
+	app.Get("/admin/lockouts", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermUsersRead), handlers.AdminLockoutsListHandler)
+	app.Delete("/admin/lockouts/:kind/:value", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermUsersManage), handlers.AdminLockoutDeleteHandler)
+	app.Get("/admin/roles", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermRolesManage), handlers.AdminRolesListHandler)
+	app.Post("/admin/roles", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermRolesManage), handlers.AdminRoleCreateHandler)
+	app.Delete("/admin/roles/:name", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermRolesManage), handlers.AdminRoleDeleteHandler)
+	app.Delete("/admin/public-tasks/:id", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermTasksModerate), handlers.AdminPublicTaskDeleteHandler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -1,46 +1,97 @@
 package middleware
 
 import (
-	"os"
+	"errors"
 	"strings"
 
+	"go_taskmanagement/auth"
+
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-// Get JWT secret from environment variable
-func getJWTSecret() []byte {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		secret = "gizliAnahtar" // Fallback for development
-	}
-	return []byte(secret)
-}
-
-// AuthMiddleware JWT doğrulaması yapar
+// AuthMiddleware JWT ya da API token doğrulaması yapar
 func AuthMiddleware(c *fiber.Ctx) error {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token gerekli"})
 	}
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return getJWTSecret(), nil
-	})
-	if err != nil || !token.Valid {
+	if auth.IsAPIToken(tokenString) {
+		return apiTokenAuth(c, tokenString)
+	}
+	claims, err := auth.Verify(tokenString)
+	switch {
+	case errors.Is(err, auth.ErrTokenExpired):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token süresi dolmuş"})
+	case errors.Is(err, auth.ErrMissingUser):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token kullanıcı bilgisi içermiyor"})
+	case err != nil:
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Geçersiz token"})
 	}
-	if claims, ok := token.Claims.(jwt.MapClaims); ok {
-		// Store both user_id and other claims for handlers
-		if uidFloat, ok := claims["user_id"].(float64); ok {
-			c.Locals("user_id", uint(uidFloat))
+
+	revoked, err := auth.Revocations.IsRevoked(claims.ID, claims.UserID, claims.IssuedAt.Time)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Token doğrulanamadı"})
+	}
+	if revoked {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token iptal edilmiş"})
+	}
+
+	// Store both user_id and other claims for handlers
+	c.Locals("user_id", claims.UserID)
+	c.Locals("username", claims.Username)
+	c.Locals("email", claims.Email)
+	c.Locals("role", claims.Role)
+	c.Locals("permissions", claims.Permissions)
+	c.Locals("jti", claims.ID)
+	c.Locals("token_expires_at", claims.ExpiresAt.Time)
+	return c.Next()
+}
+
+// RequirePermission yalnızca verilen izinlerin hepsine sahip kullanıcıları geçirir.
+// AuthMiddleware'den sonra kullanılır; izinler token'daki rolden gelir.
+func RequirePermission(perms ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		granted, _ := c.Locals("permissions").([]string)
+		if !auth.HasPermissions(granted, perms...) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Bu işlem için yetkiniz yok"})
 		}
-		if username, ok := claims["username"].(string); ok {
-			c.Locals("username", username)
+		return c.Next()
+	}
+}
+
+// apiTokenAuth accepts an API token. The user is only set once RequireScope has checked
+// the token's scopes, so routes that declare no scope (logout, token management, admin)
+// reject API tokens the same way they reject anonymous requests.
+func apiTokenAuth(c *fiber.Ctx, raw string) error {
+	identity, err := auth.AuthenticateAPIToken(raw)
+	switch {
+	case errors.Is(err, auth.ErrTokenExpired):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token süresi dolmuş"})
+	case errors.Is(err, auth.ErrTokenInvalid):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Geçersiz token"})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Token doğrulanamadı"})
+	}
+	c.Locals("api_token", identity)
+	return c.Next()
+}
+
+// RequireScope API token ile gelen istekleri yalnızca token verilen kapsama sahipse
+// geçirir. AuthMiddleware'den sonra kullanılır; JWT oturumları tüm kapsamlara sahiptir.
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		identity, ok := c.Locals("api_token").(*auth.APIIdentity)
+		if !ok {
+			return c.Next()
 		}
-		if email, ok := claims["email"].(string); ok {
-			c.Locals("email", email)
+		if !auth.HasScope(identity.Scopes, scope) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "API token'ın bu işlem için " + scope + " kapsamı yok"})
 		}
+		c.Locals("user_id", identity.UserID)
+		c.Locals("username", identity.Username)
+		c.Locals("email", identity.Email)
+		c.Locals("role", identity.Role)
+		return c.Next()
 	}
-	return c.Next()
 }
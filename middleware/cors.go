@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+// CORS tarayıcı istemcileri için CORS ayarlarını uygular. Koşullu istekler için
+// If-Match/If-None-Match kabul edilir ve ETag okunabilir olur.
+func CORS() fiber.Handler {
+	return cors.New(cors.Config{
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:  "Origin,Content-Type,Accept,Authorization,If-Match,If-None-Match",
+		ExposeHeaders: "ETag",
+	})
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Task activity actions
+const (
+	ActivityCreated  = "created"
+	ActivityUpdated  = "updated"
+	ActivityDeleted  = "deleted"
+	ActivityRestored = "restored"
+	ActivityPurged   = "purged"
+)
+
+// TaskActivity is an immutable history entry for a change made to a task.
+// Entries are kept after the task is purged, so there is no foreign key.
+type TaskActivity struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TaskID    uint      `json:"task_id" gorm:"not null;index"`
+	ActorID   uint      `json:"actor_id" gorm:"not null"` // 0 when the trash purger removed the task
+	Action    string    `json:"action" gorm:"not null"`   // created, updated, deleted, restored, purged
+	Field     string    `json:"field,omitempty"`          // Set for updates only
+	OldValue  string    `json:"old_value,omitempty"`
+	NewValue  string    `json:"new_value,omitempty"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var TaskActivities = []TaskActivity{}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// APIToken is a personal access token for scripts and CI. Only the SHA-256 hash of the
+// token is stored; the prefix lets the user recognise it in listings.
+type APIToken struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"-" gorm:"not null;index"`
+	Name       string     `json:"name" gorm:"not null" example:"ci"`
+	Prefix     string     `json:"prefix" gorm:"not null" example:"gtm_Q2xh"`
+	TokenHash  string     `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes     []string   `json:"scopes" gorm:"serializer:json;type:jsonb;not null" example:"tasks:read"`
+	ExpiresAt  *time.Time `json:"expires_at"`   // Nil means the token does not expire
+	LastUsedAt *time.Time `json:"last_used_at"` // Updated at most once a minute
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var APITokens = []APIToken{}
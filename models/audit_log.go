@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Audit log actions
+const (
+	AuditLoginLocked   = "login_locked"
+	AuditLoginUnlocked = "login_unlocked"
+)
+
+// AuditLog is an immutable record of a security event
+type AuditLog struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Action    string    `json:"action" gorm:"not null;index"`   // login_locked, login_unlocked
+	Subject   string    `json:"subject" gorm:"not null;index"`  // Lockout key, e.g. "account:ayse@example.com"
+	UserID    *uint     `json:"user_id,omitempty" gorm:"index"` // Account the event is about, when known
+	ActorID   *uint     `json:"actor_id,omitempty"`             // Admin who acted; empty for automatic events
+	IP        string    `json:"ip,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var AuditLogs = []AuditLog{}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// ChecklistItem is a lightweight to-do line inside a task
+type ChecklistItem struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	TaskID    uint       `json:"task_id" gorm:"not null;index"`
+	Text      string     `json:"text" gorm:"not null"`
+	Done      bool       `json:"done" gorm:"not null;default:false"`
+	DoneAt    *time.Time `json:"done_at,omitempty"`
+	Rank      string     `json:"rank" gorm:"not null;default:''"` // Position in the checklist, see package rank
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ChecklistSummary counts the checklist items of a task
+type ChecklistSummary struct {
+	Done  int `json:"done" example:"2"`
+	Total int `json:"total" example:"5"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var ChecklistItems = []ChecklistItem{}
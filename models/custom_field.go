@@ -0,0 +1,69 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Custom field types
+const (
+	CustomFieldNumber       = "number"
+	CustomFieldDate         = "date" // YYYY-MM-DD
+	CustomFieldSingleSelect = "single_select"
+	CustomFieldMultiSelect  = "multi_select"
+	CustomFieldText         = "text"
+	CustomFieldUser         = "user" // User ID
+)
+
+// CustomFieldTypes lists the allowed values of CustomField.Type
+var CustomFieldTypes = []string{
+	CustomFieldNumber, CustomFieldDate, CustomFieldSingleSelect,
+	CustomFieldMultiSelect, CustomFieldText, CustomFieldUser,
+}
+
+// CustomField defines an extra typed field that the tasks of a project can carry
+type CustomField struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ProjectID uint      `json:"project_id" gorm:"not null;uniqueIndex:idx_custom_field_key"`
+	Key       string    `json:"key" gorm:"not null;uniqueIndex:idx_custom_field_key"` // Used in task.custom_fields and list queries
+	Name      string    `json:"name" gorm:"not null"`
+	Type      string    `json:"type" gorm:"not null" enums:"number,date,single_select,multi_select,text,user"`
+	Options   []string  `json:"options,omitempty" gorm:"serializer:json;type:jsonb"` // Select types only
+	Required  bool      `json:"required"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CustomFieldValues holds a task's custom field values by field key.
+// It is stored as a single JSONB column so new fields need no schema change.
+type CustomFieldValues map[string]interface{}
+
+// Value implements driver.Valuer
+func (v CustomFieldValues) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// Scan implements sql.Scanner
+func (v *CustomFieldValues) Scan(src interface{}) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return fmt.Errorf("unsupported custom field value type %T", src)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var CustomFields = []CustomField{}
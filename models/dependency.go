@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// TaskDependency records that one task blocks another: the blocked task
+// should not be completed while the blocker is still open.
+type TaskDependency struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	BlockerID uint      `json:"blocker_id" gorm:"not null;uniqueIndex:idx_task_dependency;index"`
+	BlockedID uint      `json:"blocked_id" gorm:"not null;uniqueIndex:idx_task_dependency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var TaskDependencies = []TaskDependency{}
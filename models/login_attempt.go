@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// LoginAttempt holds the failed login count of one lockout key, an account
+// ("account:<email>") or a client IP ("ip:<address>")
+type LoginAttempt struct {
+	Key           string     `json:"key" gorm:"primaryKey"`
+	Failures      int        `json:"failures" gorm:"not null"`
+	LastFailureAt time.Time  `json:"last_failure_at" gorm:"not null;index"`
+	LockedUntil   *time.Time `json:"locked_until" gorm:"index"`
+}
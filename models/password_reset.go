@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// PasswordReset is a single-use password reset token. Only the SHA-256 hash of the
+// token is stored.
+type PasswordReset struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var PasswordResets = []PasswordReset{}
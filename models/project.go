@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go_taskmanagement/workflow"
+)
+
+// Project groups tasks and defines the workflow their statuses follow
+type Project struct {
+	ID        uint              `json:"id" gorm:"primaryKey"`
+	UserID    uint              `json:"user_id" gorm:"not null;index"`
+	Name      string            `json:"name" gorm:"not null"`
+	Workflow  workflow.Workflow `json:"workflow" gorm:"serializer:json;type:jsonb;not null"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var Projects = []Project{}
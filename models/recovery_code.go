@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// RecoveryCode is a single-use 2FA code for when the authenticator is lost. Only the
+// SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"not null;index"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var RecoveryCodes = []RecoveryCode{}
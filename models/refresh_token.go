@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// RefreshToken is an opaque, single-use token that can be exchanged for a new access token.
+// Only the SHA-256 hash of the token is stored. Every rotation creates a new token in the
+// same family, so replaying an already used token can revoke the whole chain.
+type RefreshToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	FamilyID  string     `json:"family_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`    // Set once the token has been rotated
+	RevokedAt *time.Time `json:"revoked_at,omitempty"` // Set when the family is revoked
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var RefreshTokens = []RefreshToken{}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// RevokedToken marks access tokens that must be rejected before they expire.
+// A row with a JTI revokes that single token; a row without one revokes every
+// token of the user issued before RevokedAt ("log out everywhere").
+type RevokedToken struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	JTI       string    `json:"jti" gorm:"index"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	RevokedAt time.Time `json:"revoked_at" gorm:"not null"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"` // Token expiry plus the verifier leeway; after this the revoked tokens are rejected anyway
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Role is a custom role with a set of permissions. The built-in "user" and "admin"
+// roles are defined in code and not stored.
+type Role struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"not null;uniqueIndex" example:"moderator"`
+	Permissions []string  `json:"permissions" gorm:"serializer:json;type:jsonb;not null" example:"tasks:moderate"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var Roles = []Role{}
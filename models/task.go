@@ -1,22 +1,74 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type Task struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	UserID      uint           `json:"user_id" gorm:"not null"`
-	Title       string         `json:"title" gorm:"not null"`
-	Description string         `json:"description"`
-	Status      string         `json:"status" gorm:"default:pending"`  // pending, in_progress, completed
-	Priority    string         `json:"priority" gorm:"default:medium"` // low, medium, high
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
-	User        User           `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	ID               uint              `json:"id" gorm:"primaryKey"`
+	UserID           uint              `json:"user_id" gorm:"not null"`
+	ProjectID        *uint             `json:"project_id,omitempty" gorm:"index"` // Tasks without a project follow the default workflow
+	Title            string            `json:"title" gorm:"not null"`
+	Description      string            `json:"description"`                                            // CommonMark
+	Status           string            `json:"status" gorm:"default:pending"`                          // One of the statuses of the task's workflow
+	Priority         string            `json:"priority" gorm:"default:medium" enums:"low,medium,high"` // low, medium, high
+	Estimate         *float64          `json:"estimate,omitempty" example:"3"`                         // Hours or story points, see EstimateUnit
+	EstimateUnit     string            `json:"estimate_unit,omitempty" enums:"hours,points"`
+	Tags             Tags              `json:"tags,omitempty" gorm:"type:jsonb" swaggertype:"array,string"`
+	DueDate          *time.Time        `json:"due_date,omitempty"`
+	ParentID         *uint             `json:"parent_id,omitempty" gorm:"index"`                               // Set on subtasks
+	Rank             string            `json:"rank" gorm:"not null;default:'';index"`                          // Position in the status column, see package rank
+	CustomFields     CustomFieldValues `json:"custom_fields,omitempty" gorm:"type:jsonb" swaggertype:"object"` // Values of the project's custom fields
+	Version          uint              `json:"version" gorm:"not null;default:1"`                              // Optimistic locking, exposed as ETag
+	Blocked          bool              `json:"blocked" gorm:"-"`                                               // Computed: an open task blocks this one
+	TrackedSeconds   int64             `json:"tracked_seconds" gorm:"-"`                                       // Computed: total of the task's time entries
+	Checklist        ChecklistSummary  `json:"checklist" gorm:"-"`                                             // Computed: done and total checklist items
+	DescriptionTasks ChecklistSummary  `json:"description_tasks" gorm:"-"`                                     // Computed: task list checkboxes in the description
+	DescriptionHTML  string            `json:"description_html,omitempty" gorm:"-"`                            // Rendered description, only with ?render=html
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt    `json:"-" gorm:"index"` // Soft delete
+	User             User              `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TaskPriorities lists the allowed values of Task.Priority
+var TaskPriorities = []string{"low", "medium", "high"}
+
+// EstimateUnits lists the allowed values of Task.EstimateUnit
+var EstimateUnits = []string{"hours", "points"}
+
+// Tags are free-form task labels, stored as a JSONB array
+type Tags []string
+
+// Value implements driver.Valuer
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(t)
+	return string(b), err
+}
+
+// Scan implements sql.Scanner
+func (t *Tags) Scan(src interface{}) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return fmt.Errorf("unsupported tags type %T", src)
+	}
+	return json.Unmarshal(data, t)
 }
 
 // In-memory storage for backward compatibility (will be removed after DB migration)
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// TaskTemplate describes a set of tasks that is created again and again, such as
+// an onboarding. Texts may contain {{variable}} placeholders that are filled in
+// when the template is instantiated.
+type TaskTemplate struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	UserID    uint         `json:"user_id" gorm:"not null;index"`
+	Name      string       `json:"name" gorm:"not null" example:"Yeni çalışan"`
+	ProjectID *uint        `json:"project_id,omitempty"` // Tasks are created in this project
+	Task      TemplateTask `json:"task" gorm:"serializer:json;type:jsonb;not null"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// TemplateTask is a task in a template. Subtasks become tasks with the created
+// task as their parent.
+type TemplateTask struct {
+	Title       string         `json:"title" example:"{{name}} için işe alım"`
+	Description string         `json:"description,omitempty"`
+	Priority    string         `json:"priority,omitempty" enums:"low,medium,high"`
+	Tags        []string       `json:"tags,omitempty"`
+	Checklist   []string       `json:"checklist,omitempty"`               // Checklist item texts
+	DueInDays   *int           `json:"due_in_days,omitempty" example:"3"` // Due date relative to the start date
+	Subtasks    []TemplateTask `json:"subtasks,omitempty"`
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var TaskTemplates = []TaskTemplate{}
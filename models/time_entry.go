@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// TimeEntry is time a user spent on a task. A running timer is an entry without EndedAt;
+// each user has at most one.
+type TimeEntry struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	UserID          uint       `json:"user_id" gorm:"not null;index;uniqueIndex:idx_time_entries_running,where:ended_at IS NULL"`
+	TaskID          uint       `json:"task_id" gorm:"not null;index"`
+	StartedAt       time.Time  `json:"started_at" gorm:"not null;index"`
+	EndedAt         *time.Time `json:"ended_at"` // nil while the timer is running
+	DurationSeconds int64      `json:"duration_seconds"`
+	Note            string     `json:"note"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Running reports whether the entry is a timer that has not been stopped
+func (e *TimeEntry) Running() bool {
+	return e.EndedAt == nil
+}
+
+// SecondsAt returns the tracked seconds, counting a running timer up to now
+func (e *TimeEntry) SecondsAt(now time.Time) int64 {
+	if e.Running() {
+		return int64(now.Sub(e.StartedAt).Seconds())
+	}
+	return e.DurationSeconds
+}
+
+// In-memory storage for backward compatibility (will be removed after DB migration)
+var TimeEntries = []TimeEntry{}
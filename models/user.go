@@ -7,14 +7,19 @@ import (
 )
 
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Username  string         `json:"username" gorm:"unique;not null"`
-	Email     string         `json:"email" gorm:"unique;not null"`
-	Password  string         `json:"-" gorm:"not null"` // Don't expose password in JSON
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
-	Tasks     []Task         `json:"tasks,omitempty" gorm:"foreignKey:UserID"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Username        string         `json:"username" gorm:"unique;not null"`
+	Email           string         `json:"email" gorm:"unique;not null"`
+	Password        string         `json:"-" gorm:"not null"`                   // Don't expose password in JSON
+	Role            string         `json:"role" gorm:"not null;default:'user'"` // Built-in or custom role, see package auth
+	EmailVerifiedAt *time.Time     `json:"email_verified_at"`                   // Set when the user opens the verification link
+	TOTPSecret      string         `json:"-"`                                   // Authenticator secret; pending until TOTPEnabledAt is set
+	TOTPEnabledAt   *time.Time     `json:"totp_enabled_at"`                     // Set once 2FA is confirmed with a first code
+	TOTPLastStep    int64          `json:"-"`                                   // Period of the last accepted code, so a code can't be replayed
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+	Tasks           []Task         `json:"tasks,omitempty" gorm:"foreignKey:UserID"`
 }
 
 // In-memory storage for backward compatibility (will be removed after DB migration)
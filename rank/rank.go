@@ -0,0 +1,87 @@
+// Package rank generates LexoRank-style ordering keys. Keys are base-36 strings
+// that sort lexicographically, and a new key can always be made between two
+// others, so moving an item only rewrites that item.
+package rank
+
+import "strings"
+
+const (
+	digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+	base   = len(digits)
+)
+
+// Between returns a key that sorts after prev and before next.
+// An empty prev means the start of the list and an empty next its end.
+// prev must sort before next; keys made by this package never end in "0",
+// which keeps room below every key.
+func Between(prev, next string) string {
+	if next != "" {
+		// Keep the common prefix, with prev padded by zeros
+		n := 0
+		for n < len(next) && digitAt(prev, n) == strings.IndexByte(digits, next[n]) {
+			n++
+		}
+		if n > 0 {
+			return next[:n] + Between(suffix(prev, n), next[n:])
+		}
+	}
+
+	lo := digitAt(prev, 0)
+	hi := base
+	if next != "" {
+		hi = strings.IndexByte(digits, next[0])
+	}
+	if hi-lo > 1 {
+		return string(digits[(lo+hi)/2])
+	}
+	// Adjacent digits: a longer next leaves room with its first digit alone,
+	// otherwise keep prev's digit and go one level deeper
+	if len(next) > 1 {
+		return next[:1]
+	}
+	return string(digits[lo]) + Between(suffix(prev, 1), "")
+}
+
+// Spread returns n evenly spaced keys of equal length, used to rebalance a list
+// whose keys have grown long
+func Spread(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	width, space := 1, uint64(base)
+	for space < 2*uint64(n+1) {
+		width++
+		space *= uint64(base)
+	}
+	step := space / uint64(n+1)
+
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = encode(uint64(i+1)*step, width)
+	}
+	return keys
+}
+
+// encode writes v as a base-36 number of the given width without trailing zeros
+func encode(v uint64, width int) string {
+	buf := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		buf[i] = digits[v%uint64(base)]
+		v /= uint64(base)
+	}
+	return strings.TrimRight(string(buf), "0")
+}
+
+func digitAt(key string, i int) int {
+	if i >= len(key) {
+		return 0
+	}
+	return strings.IndexByte(digits, key[i])
+}
+
+func suffix(key string, n int) string {
+	if n >= len(key) {
+		return ""
+	}
+	return key[n:]
+}
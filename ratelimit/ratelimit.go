@@ -0,0 +1,68 @@
+// Package ratelimit is a small in-process sliding-window rate limiter for
+// endpoints that send emails or check passwords.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many calls pass between removals of idle keys
+const sweepEvery = 1024
+
+// Limiter allows at most Max events per key within Window
+type Limiter struct {
+	Max    int
+	Window time.Duration
+
+	mu    sync.Mutex
+	hits  map[string][]time.Time
+	calls int
+}
+
+// New returns a limiter allowing max events per key within window
+func New(max int, window time.Duration) *Limiter {
+	return &Limiter{Max: max, Window: window, hits: make(map[string][]time.Time)}
+}
+
+// Allow records an event for the key if it is within the limit. When it is not, the
+// event is not recorded and the returned duration says when the next one is allowed.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls++
+	if l.calls%sweepEvery == 0 {
+		for k, hits := range l.hits {
+			if len(l.recent(hits, now)) == 0 {
+				delete(l.hits, k)
+			}
+		}
+	}
+
+	hits := l.recent(l.hits[key], now)
+	if len(hits) >= l.Max {
+		l.hits[key] = hits
+		return false, hits[0].Add(l.Window).Sub(now)
+	}
+	l.hits[key] = append(hits, now)
+	return true, 0
+}
+
+// Reset forgets the events of the key
+func (l *Limiter) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.hits, key)
+}
+
+// recent drops the events that left the window. Callers hold l.mu.
+func (l *Limiter) recent(hits []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-l.Window)
+	i := 0
+	for i < len(hits) && !hits[i].After(cutoff) {
+		i++
+	}
+	return hits[i:]
+}
@@ -3,6 +3,7 @@ package contract
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -19,18 +20,37 @@ type StaticToken string
 
 func (s StaticToken) BearerToken() (string, error) { return string(s), nil }
 
-// JWT bootstrap via your login endpoint
+// JWT bootstrap via your login endpoint. Access tokens are short-lived, so once the
+// cached one is about to expire it is renewed with the rotating refresh token, and only
+// falls back to a fresh login when the refresh is rejected.
 type LoginToken struct {
 	BaseURL string
 	Email   string // Changed from User to Email to match your API
 	Pass    string
 	App     *fiber.App // For in-process testing
 
-	mu    sync.Mutex
-	token string
-	exp   time.Time
+	mu      sync.Mutex
+	token   string
+	refresh string
+	exp     time.Time
 }
 
+// tokenResponse matches the TokenResponse returned by /login and /token/refresh
+type tokenResponse struct {
+	Token        string `json:"token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	User         struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+		Email    string `json:"email"`
+	} `json:"user"`
+}
+
+// refreshSkew renews tokens a little early to account for clock skew
+const refreshSkew = 30 * time.Second
+
 func (l *LoginToken) BearerToken() (string, error) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -39,53 +59,73 @@ func (l *LoginToken) BearerToken() (string, error) {
 		return l.token, nil
 	}
 
+	if l.refresh != "" {
+		body, _ := json.Marshal(map[string]string{"refresh_token": l.refresh})
+		if out, err := l.post("/token/refresh", body); err == nil {
+			l.store(out)
+			return l.token, nil
+		}
+		// Refresh token expired or was revoked; log in again
+		l.refresh = ""
+	}
+
 	// Match your login request format
 	body, _ := json.Marshal(map[string]string{
 		"email":    l.Email, // Your API uses email, not username
 		"password": l.Pass,
 	})
+	out, err := l.post("/login", body)
+	if err != nil {
+		return "", err
+	}
+	l.store(out)
+
+	return l.token, nil
+}
 
-	// Create request for Fiber in-process testing
-	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+func (l *LoginToken) store(out *tokenResponse) {
+	l.token = out.AccessToken
+	if l.token == "" {
+		l.token = out.Token // Servers that predate refresh tokens
+	}
+	l.refresh = out.RefreshToken
+	ttl := time.Duration(out.ExpiresIn) * time.Second
+	if ttl <= refreshSkew {
+		ttl = 15 * time.Minute
+	}
+	l.exp = time.Now().Add(ttl - refreshSkew)
+}
 
+func (l *LoginToken) post(path string, body []byte) (*tokenResponse, error) {
 	var resp *http.Response
 	var err error
 
 	if l.App != nil {
 		// Use Fiber in-process testing
+		req, _ := http.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
 		resp, err = l.App.Test(req, 10_000)
 	} else {
 		// Use HTTP client (for external testing)
-		req, _ = http.NewRequest(http.MethodPost, l.BaseURL+"/login", bytes.NewReader(body))
+		req, _ := http.NewRequest(http.MethodPost, l.BaseURL+path, bytes.NewReader(body))
 		req.Header.Set("Content-Type", "application/json")
 		resp, err = http.DefaultClient.Do(req)
 	}
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	// Match your LoginResponse format from OpenAPI spec
-	var out struct {
-		Token string `json:"token"` // Your API returns "token", not "access_token"
-		User  struct {
-			ID       int    `json:"id"`
-			Username string `json:"username"`
-			Email    string `json:"email"`
-		} `json:"user"`
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("POST %s: status %d", path, resp.StatusCode)
 	}
 
+	var out tokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return "", err
+		return nil, err
 	}
-
-	l.token = out.Token
-	// Set expiration (JWT tokens typically expire in 1 hour, adjust as needed)
-	l.exp = time.Now().Add(55 * time.Minute) // 55 minutes to account for clock skew
-
-	return l.token, nil
+	return &out, nil
 }
 
 // Helper function to create a login token for testing
@@ -38,9 +38,10 @@ func Test_OpenAPI_Contract(t *testing.T) {
 	}
 
 	// 3) Token source - Create a real authenticated user
-	var token TokenSource
+	var token, logoutToken TokenSource
 	if v := os.Getenv("TEST_BEARER"); v != "" {
 		token = StaticToken(v)
+		logoutToken = token
 	} else {
 		// Create a login token that will register and login automatically
 		email := fmt.Sprintf("test_%s@example.com", generateRandomString())
@@ -56,6 +57,12 @@ func Test_OpenAPI_Contract(t *testing.T) {
 			Pass:    password,
 			App:     f, // Pass Fiber app for in-process testing
 		}
+
+		// Logout revokes the caller's tokens, so it runs as a separate user to keep
+		// the shared token valid for the other operations
+		logoutEmail := fmt.Sprintf("test_%s@example.com", generateRandomString())
+		RegisterUserForTest(f, logoutEmail, password)
+		logoutToken = &LoginToken{Email: logoutEmail, Pass: password, App: f}
 	}
 
 	// 4) Iterate all operations dynamically - FIX: Actually run the tests!
@@ -75,6 +82,9 @@ func Test_OpenAPI_Contract(t *testing.T) {
 					Method:   method,
 					Token:    token,
 				}
+				if strings.HasPrefix(path, "/logout") {
+					buildInput.Token = logoutToken
+				}
 
 				// Special case for login endpoint - use registered user credentials
 				if method == "POST" && path == "/login" {
@@ -100,3 +100,33 @@ func TestOpenAPISpecLoading(t *testing.T) {
 
 	t.Logf("✓ OpenAPI spec loaded successfully with %d paths", len(doc.Paths.Map()))
 }
+
+// Browsers need the conditional request headers allowed and ETag exposed
+func TestCORSConditionalHeaders(t *testing.T) {
+	f := app.NewApp()
+
+	req, _ := http.NewRequest("OPTIONS", "/tasks/1", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", "PUT")
+	req.Header.Set("Access-Control-Request-Headers", "If-Match")
+	resp, err := f.Test(req, 10000)
+	if err != nil {
+		t.Fatalf("preflight: %v", err)
+	}
+	allowed := resp.Header.Get("Access-Control-Allow-Headers")
+	for _, h := range []string{"If-Match", "If-None-Match"} {
+		if !strings.Contains(allowed, h) {
+			t.Errorf("expected %s in Access-Control-Allow-Headers, got %q", h, allowed)
+		}
+	}
+
+	req, _ = http.NewRequest("GET", "/tasks/public", nil)
+	req.Header.Set("Origin", "https://example.com")
+	resp, err = f.Test(req, 10000)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if exposed := resp.Header.Get("Access-Control-Expose-Headers"); !strings.Contains(exposed, "ETag") {
+		t.Errorf("expected ETag in Access-Control-Expose-Headers, got %q", exposed)
+	}
+}
@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/handlers"
+	"go_taskmanagement/middleware"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// registerAdmin registers a user whose address is in ADMIN_EMAILS and verifies it, which
+// is what makes them an admin
+func registerAdmin(t *testing.T, app *fiber.App, username, email string) {
+	t.Helper()
+	resp, out := doJSON(t, app, "POST", "/register", `{"username":"`+username+`","email":"`+email+`","password":"secret123"}`, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register %s: expected 201, got %d %v", email, resp.StatusCode, out)
+	}
+	id := uint(out["user"].(map[string]interface{})["id"].(float64))
+	verify := fiber.New()
+	verify.Get("/verify-email", handlers.VerifyEmailHandler)
+	link := "/verify-email?token=" + url.QueryEscape(auth.NewEmailVerificationToken(id, email))
+	if resp, out := doJSON(t, verify, "GET", link, "", nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("verify %s: expected 200, got %d %v", email, resp.StatusCode, out)
+	}
+}
+
+// newAdminTestApp registers ayse as a regular user and root as the admin from ADMIN_EMAILS
+func newAdminTestApp(t *testing.T) *fiber.App {
+	t.Helper()
+	t.Setenv("ADMIN_EMAILS", "root@example.com")
+	app := newAuthTestApp(t)
+	models.Roles = []models.Role{}
+	publicTasks := models.PublicTasks
+	t.Cleanup(func() { models.PublicTasks = publicTasks })
+	models.PublicTasks = slices.Clone(publicTasks)
+
+	app.Get("/admin/users", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermUsersRead), handlers.AdminUsersListHandler)
+	app.Put("/admin/users/:id/role", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermUsersManage), handlers.AdminUserRoleHandler)
+	app.Post("/admin/roles", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermRolesManage), handlers.AdminRoleCreateHandler)
+	app.Delete("/admin/roles/:name", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermRolesManage), handlers.AdminRoleDeleteHandler)
+	app.Delete("/admin/public-tasks/:id", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermTasksModerate), handlers.AdminPublicTaskDeleteHandler)
+
+	registerAdmin(t, app, "root", "root@example.com")
+	return app
+}
+
+func TestAdminEndpointsRequirePermission(t *testing.T) {
+	app := newAdminTestApp(t)
+	user := login(t, app)
+	admin := loginAs(t, app, "root@example.com")
+	if user["user"].(map[string]interface{})["role"] != "user" || admin["user"].(map[string]interface{})["role"] != "admin" {
+		t.Fatalf("unexpected roles: %v %v", user["user"], admin["user"])
+	}
+
+	if resp, out := doJSON(t, app, "GET", "/admin/users", "", bearer(user)); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("user: expected 403, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "GET", "/admin/users", "", nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("anonymous: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	if resp, _ := doJSON(t, app, "GET", "/admin/users", "", bearer(admin)); resp.StatusCode != http.StatusOK {
+		t.Errorf("admin: expected 200, got %d", resp.StatusCode)
+	}
+	if resp, out := doJSON(t, app, "DELETE", "/admin/public-tasks/1", "", bearer(admin)); resp.StatusCode != http.StatusOK {
+		t.Errorf("moderate: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if len(models.PublicTasks) != 1 {
+		t.Errorf("expected 1 public task left, got %d", len(models.PublicTasks))
+	}
+}
+
+func TestCustomRoleGrantsPermissions(t *testing.T) {
+	app := newAdminTestApp(t)
+	admin := loginAs(t, app, "root@example.com")
+	user := login(t, app)
+
+	if resp, out := doJSON(t, app, "POST", "/admin/roles", `{"name":"moderator","permissions":["tasks:moderate","bilinmeyen"]}`, bearer(admin)); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("unknown permission: expected 422, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "POST", "/admin/roles", `{"name":"admin","permissions":[]}`, bearer(admin)); resp.StatusCode != http.StatusConflict {
+		t.Errorf("built-in name: expected 409, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "POST", "/admin/roles", `{"name":"moderator","permissions":["tasks:moderate"]}`, bearer(admin)); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create role: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "PUT", "/admin/users/1/role", `{"role":"moderator"}`, bearer(admin)); resp.StatusCode != http.StatusOK || out["role"] != "moderator" {
+		t.Fatalf("assign role: expected 200, got %d %v", resp.StatusCode, out)
+	}
+
+	// The old token carries the old permissions and is revoked; a refreshed one has the new ones
+	if resp, out := doJSON(t, app, "DELETE", "/admin/public-tasks/2", "", bearer(user)); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("old token: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	_, moderator := refresh(t, app, user["refresh_token"])
+	if resp, out := doJSON(t, app, "DELETE", "/admin/public-tasks/2", "", bearer(moderator)); resp.StatusCode != http.StatusOK {
+		t.Errorf("moderator: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "GET", "/admin/users", "", bearer(moderator)); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("moderator listing users: expected 403, got %d %v", resp.StatusCode, out)
+	}
+
+	if resp, out := doJSON(t, app, "DELETE", "/admin/roles/moderator", "", bearer(admin)); resp.StatusCode != http.StatusConflict {
+		t.Errorf("delete role in use: expected 409, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestLastAdminCannotBeDemoted(t *testing.T) {
+	app := newAdminTestApp(t)
+	admin := loginAs(t, app, "root@example.com")
+	user := login(t, app)
+
+	// Nobody changes their own role, so the last admin can only be demoted by a
+	// custom role holding every permission
+	if resp, out := doJSON(t, app, "PUT", "/admin/users/2/role", `{"role":"user"}`, bearer(admin)); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("own role: expected 403, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "POST", "/admin/roles", `{"name":"superuser","permissions":["tasks:moderate","users:read","users:manage","roles:manage"]}`, bearer(admin)); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create role: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "PUT", "/admin/users/1/role", `{"role":"superuser"}`, bearer(admin)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("assign role: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	_, superuser := refresh(t, app, user["refresh_token"])
+	if resp, out := doJSON(t, app, "PUT", "/admin/users/2/role", `{"role":"user"}`, bearer(superuser)); resp.StatusCode != http.StatusConflict {
+		t.Errorf("last admin: expected 409, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "PUT", "/admin/users/1/role", `{"role":"yok"}`, bearer(admin)); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("unknown role: expected 422, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "PUT", "/admin/users/1/role", `{"role":"admin"}`, bearer(admin)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("promote: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	_, promoted := refresh(t, app, superuser["refresh_token"])
+	if resp, out := doJSON(t, app, "PUT", "/admin/users/2/role", `{"role":"user"}`, bearer(promoted)); resp.StatusCode != http.StatusOK {
+		t.Errorf("demote with another admin: expected 200, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestRoleAssignmentCannotEscalate(t *testing.T) {
+	app := newAdminTestApp(t)
+	admin := loginAs(t, app, "root@example.com")
+	user := login(t, app)
+	if resp, out := doJSON(t, app, "POST", "/register", `{"username":"mehmet","email":"mehmet@example.com","password":"secret123"}`, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d %v", resp.StatusCode, out)
+	}
+
+	if resp, out := doJSON(t, app, "POST", "/admin/roles", `{"name":"manager","permissions":["users:read","users:manage"]}`, bearer(admin)); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create role: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "PUT", "/admin/users/1/role", `{"role":"manager"}`, bearer(admin)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("assign role: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	_, manager := refresh(t, app, user["refresh_token"])
+
+	cases := []struct {
+		name, path, body string
+		status           int
+	}{
+		{"promote self", "/admin/users/1/role", `{"role":"admin"}`, http.StatusForbidden},
+		{"grant more than held", "/admin/users/3/role", `{"role":"admin"}`, http.StatusForbidden},
+		{"demote an admin", "/admin/users/2/role", `{"role":"user"}`, http.StatusForbidden},
+		{"grant what is held", "/admin/users/3/role", `{"role":"manager"}`, http.StatusOK},
+		{"take back what is held", "/admin/users/3/role", `{"role":"user"}`, http.StatusOK},
+	}
+	for _, tc := range cases {
+		if resp, out := doJSON(t, app, "PUT", tc.path, tc.body, bearer(manager)); resp.StatusCode != tc.status {
+			t.Errorf("%s: expected %d, got %d %v", tc.name, tc.status, resp.StatusCode, out)
+		}
+	}
+	if models.Users[1].Role != auth.RoleAdmin || models.Users[0].Role != "manager" {
+		t.Errorf("roles changed: %s %s", models.Users[0].Role, models.Users[1].Role)
+	}
+}
+
+func TestAdminEmailsNeedVerification(t *testing.T) {
+	t.Setenv("ADMIN_EMAILS", "root@example.com")
+	env := newAccountTestApp(t)
+
+	// Anyone can sign up with the address; it grants nothing until the owner verifies it
+	resp, out := doJSON(t, env.app, "POST", "/register", `{"username":"root","email":"root@example.com","password":"secret123"}`, nil)
+	if resp.StatusCode != http.StatusCreated || out["user"].(map[string]interface{})["role"] != auth.RoleUser {
+		t.Fatalf("register: expected 201 as user, got %d %v", resp.StatusCode, out)
+	}
+	tokens := loginAs(t, env.app, "root@example.com")
+	if role := tokens["user"].(map[string]interface{})["role"]; role != auth.RoleUser {
+		t.Fatalf("unverified login: expected role user, got %v", role)
+	}
+
+	if resp, out := doJSON(t, env.app, "GET", verificationLink(t, env.mails.expect(t)), "", nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("verify: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	_, refreshed := refresh(t, env.app, tokens["refresh_token"])
+	if role := refreshed["user"].(map[string]interface{})["role"]; role != auth.RoleAdmin {
+		t.Errorf("after verification: expected role admin, got %v", role)
+	}
+
+	// Other verified addresses stay regular users
+	doJSON(t, env.app, "GET", verificationLink(t, env.verification), "", nil)
+	if models.Users[0].Role != auth.RoleUser {
+		t.Errorf("other user: expected role user, got %s", models.Users[0].Role)
+	}
+}
@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/handlers"
+	"go_taskmanagement/middleware"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newAPITokenTestApp(t *testing.T) (*fiber.App, map[string]interface{}) {
+	t.Helper()
+	models.Users = []models.User{}
+	models.RefreshTokens = []models.RefreshToken{}
+	models.APITokens = []models.APIToken{}
+	models.Tasks = []models.Task{}
+	auth.Revocations = auth.NewRevocationStore()
+
+	read := middleware.RequireScope(auth.ScopeTasksRead)
+	write := middleware.RequireScope(auth.ScopeTasksWrite)
+	app := fiber.New()
+	app.Post("/register", handlers.RegisterHandler)
+	app.Post("/login", handlers.LoginHandler)
+	app.Get("/tasks", middleware.AuthMiddleware, read, handlers.TasksListHandler)
+	app.Post("/tasks", middleware.AuthMiddleware, write, handlers.TaskCreateHandler)
+	app.Post("/logout/all", middleware.AuthMiddleware, handlers.LogoutAllHandler)
+	app.Get("/api-tokens", middleware.AuthMiddleware, handlers.APITokensListHandler)
+	app.Post("/api-tokens", middleware.AuthMiddleware, handlers.APITokenCreateHandler)
+	app.Delete("/api-tokens/:id", middleware.AuthMiddleware, handlers.APITokenDeleteHandler)
+
+	if resp, out := doJSON(t, app, "POST", "/register", `{"username":"ayse","email":"ayse@example.com","password":"secret123"}`, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	return app, loginAs(t, app, "ayse@example.com")
+}
+
+func createAPIToken(t *testing.T, app *fiber.App, session map[string]interface{}, body string) map[string]interface{} {
+	t.Helper()
+	resp, out := doJSON(t, app, "POST", "/api-tokens", body, bearer(session))
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create api token: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	return out
+}
+
+func apiToken(token map[string]interface{}) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token["token"].(string)}
+}
+
+func TestAPITokenScopes(t *testing.T) {
+	app, session := newAPITokenTestApp(t)
+	reader := createAPIToken(t, app, session, `{"name":"rapor","scopes":["tasks:read"]}`)
+	writer := createAPIToken(t, app, session, `{"name":"ci","scopes":["tasks:write"],"expires_in_days":30}`)
+
+	if len(models.APITokens) != 2 || models.APITokens[0].TokenHash == reader["token"] {
+		t.Fatalf("expected two hashed tokens, got %v", models.APITokens)
+	}
+	if writer["expires_at"] == nil || reader["expires_at"] != nil {
+		t.Errorf("unexpected expiry: reader %v, writer %v", reader["expires_at"], writer["expires_at"])
+	}
+
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", apiToken(reader)); resp.StatusCode != http.StatusOK {
+		t.Errorf("read with tasks:read: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, apiToken(reader)); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("write with tasks:read: expected 403, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, apiToken(writer)); resp.StatusCode != http.StatusCreated {
+		t.Errorf("write with tasks:write: expected 201, got %d %v", resp.StatusCode, out)
+	} else if out["user_id"] != float64(1) {
+		t.Errorf("task not owned by the token's user: %v", out)
+	}
+	// tasks:write includes reading
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", apiToken(writer)); resp.StatusCode != http.StatusOK {
+		t.Errorf("read with tasks:write: expected 200, got %d %v", resp.StatusCode, out)
+	}
+
+	// Routes without a scope need a login session
+	for _, path := range []string{"/api-tokens", "/logout/all"} {
+		method := "GET"
+		if path == "/logout/all" {
+			method = "POST"
+		}
+		if resp, out := doJSON(t, app, method, path, "", apiToken(writer)); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("%s %s with api token: expected 401, got %d %v", method, path, resp.StatusCode, out)
+		}
+	}
+}
+
+func TestAPITokenLifecycle(t *testing.T) {
+	app, session := newAPITokenTestApp(t)
+	token := createAPIToken(t, app, session, `{"name":"ci","scopes":["tasks:read"]}`)
+
+	resp, _ := doJSON(t, app, "GET", "/tasks", "", apiToken(token))
+	if resp.StatusCode != http.StatusOK || models.APITokens[0].LastUsedAt == nil {
+		t.Fatalf("expected last_used_at after use, got %d %v", resp.StatusCode, models.APITokens[0].LastUsedAt)
+	}
+
+	// Listings never include the secret
+	req := httptest.NewRequest("GET", "/api-tokens", nil)
+	req.Header.Set("Authorization", bearer(session)["Authorization"])
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var listed []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&listed)
+	if len(listed) != 1 || listed[0]["token"] != nil || listed[0]["prefix"] == "" || listed[0]["last_used_at"] == nil {
+		t.Errorf("unexpected listing: %v", listed)
+	}
+
+	// Logging out everywhere ends sessions but not API tokens
+	doJSON(t, app, "POST", "/logout/all", "", bearer(session))
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", apiToken(token)); resp.StatusCode != http.StatusOK {
+		t.Errorf("api token after logout all: expected 200, got %d %v", resp.StatusCode, out)
+	}
+
+	session = loginAs(t, app, "ayse@example.com")
+	if resp, out := doJSON(t, app, "DELETE", "/api-tokens/1", "", bearer(session)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("delete: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", apiToken(token)); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("deleted token: expected 401, got %d %v", resp.StatusCode, out)
+	}
+
+	// Expired tokens are rejected
+	expired := createAPIToken(t, app, session, `{"name":"eski","scopes":["tasks:read"],"expires_in_days":1}`)
+	past := time.Now().Add(-time.Minute)
+	models.APITokens[len(models.APITokens)-1].ExpiresAt = &past
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", apiToken(expired)); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expired token: expected 401, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestAPITokenValidation(t *testing.T) {
+	app, session := newAPITokenTestApp(t)
+	for _, body := range []string{
+		`{"name":"","scopes":["tasks:read"]}`,
+		`{"name":"ci","scopes":[]}`,
+		`{"name":"ci","scopes":["users:manage"]}`,
+		`{"name":"ci","scopes":["tasks:read"],"expires_in_days":0}`,
+	} {
+		if resp, out := doJSON(t, app, "POST", "/api-tokens", body, bearer(session)); resp.StatusCode != http.StatusUnprocessableEntity {
+			t.Errorf("%s: expected 422, got %d %v", body, resp.StatusCode, out)
+		}
+	}
+}
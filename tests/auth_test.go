@@ -0,0 +1,387 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/handlers"
+	"go_taskmanagement/middleware"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newAuthTestApp(t *testing.T) *fiber.App {
+	t.Helper()
+	models.Users = []models.User{}
+	models.RefreshTokens = []models.RefreshToken{}
+	models.Tasks = []models.Task{}
+	auth.Revocations = auth.NewRevocationStore()
+
+	app := fiber.New()
+	app.Post("/register", handlers.RegisterHandler)
+	app.Post("/login", handlers.LoginHandler)
+	app.Post("/token/refresh", handlers.RefreshTokenHandler)
+	app.Get("/tasks", middleware.AuthMiddleware, handlers.TasksListHandler)
+	app.Post("/logout", middleware.AuthMiddleware, handlers.LogoutHandler)
+	app.Post("/logout/all", middleware.AuthMiddleware, handlers.LogoutAllHandler)
+
+	if resp, out := doJSON(t, app, "POST", "/register", `{"username":"ayse","email":"ayse@example.com","password":"secret123"}`, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	return app
+}
+
+func login(t *testing.T, app *fiber.App) map[string]interface{} {
+	t.Helper()
+	return loginAs(t, app, "ayse@example.com")
+}
+
+func loginAs(t *testing.T, app *fiber.App, email string) map[string]interface{} {
+	t.Helper()
+	resp, out := doJSON(t, app, "POST", "/login", `{"email":"`+email+`","password":"secret123"}`, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("login: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	return out
+}
+
+func refresh(t *testing.T, app *fiber.App, token interface{}) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	return doJSON(t, app, "POST", "/token/refresh", `{"refresh_token":"`+token.(string)+`"}`, nil)
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	app := newAuthTestApp(t)
+	tokens := login(t, app)
+	if tokens["expires_in"] != float64(900) || tokens["token"] != tokens["access_token"] || tokens["refresh_token"] == "" {
+		t.Fatalf("unexpected login response: %v", tokens)
+	}
+	if len(models.RefreshTokens) != 1 || models.RefreshTokens[0].TokenHash == tokens["refresh_token"] {
+		t.Fatalf("expected one hashed refresh token, got %v", models.RefreshTokens)
+	}
+
+	resp, next := refresh(t, app, tokens["refresh_token"])
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("refresh: expected 200, got %d %v", resp.StatusCode, next)
+	}
+	if next["refresh_token"] == tokens["refresh_token"] {
+		t.Error("expected a new refresh token after rotation")
+	}
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", map[string]string{"Authorization": "Bearer " + next["access_token"].(string)}); resp.StatusCode != http.StatusOK {
+		t.Errorf("refreshed access token rejected: %d %v", resp.StatusCode, out)
+	}
+
+	// The rotated chain keeps working
+	resp, third := refresh(t, app, next["refresh_token"])
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("second refresh: expected 200, got %d %v", resp.StatusCode, third)
+	}
+	if third["user"].(map[string]interface{})["email"] != "ayse@example.com" {
+		t.Errorf("unexpected user in refresh response: %v", third["user"])
+	}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	app := newAuthTestApp(t)
+	first := login(t, app)
+	other := login(t, app)
+
+	_, second := refresh(t, app, first["refresh_token"])
+
+	// Replaying the used token revokes its successor too
+	if resp, out := refresh(t, app, first["refresh_token"]); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("reuse: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := refresh(t, app, second["refresh_token"]); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("successor after reuse: expected 401, got %d %v", resp.StatusCode, out)
+	}
+
+	// A separate login is a separate family and is not affected
+	if resp, out := refresh(t, app, other["refresh_token"]); resp.StatusCode != http.StatusOK {
+		t.Errorf("other session: expected 200, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestRefreshTokenInvalid(t *testing.T) {
+	app := newAuthTestApp(t)
+	if resp, out := doJSON(t, app, "POST", "/token/refresh", `{}`, nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("missing token: expected 400, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := refresh(t, app, "bilinmeyen"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unknown token: expected 401, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func bearer(tokens map[string]interface{}) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + tokens["access_token"].(string)}
+}
+
+func TestLogoutRevokesToken(t *testing.T) {
+	app := newAuthTestApp(t)
+	tokens := login(t, app)
+	other := login(t, app)
+
+	if resp, out := doJSON(t, app, "POST", "/logout", `{"refresh_token":"`+tokens["refresh_token"].(string)+`"}`, bearer(tokens)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("logout: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", bearer(tokens)); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("revoked token: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := refresh(t, app, tokens["refresh_token"]); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("refresh after logout: expected 401, got %d %v", resp.StatusCode, out)
+	}
+
+	// Other sessions stay logged in
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", bearer(other)); resp.StatusCode != http.StatusOK {
+		t.Errorf("other session: expected 200, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestLogoutEverywhere(t *testing.T) {
+	app := newAuthTestApp(t)
+	first := login(t, app)
+	second := login(t, app)
+
+	if resp, out := doJSON(t, app, "POST", "/logout/all", "", bearer(first)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("logout all: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	for _, tokens := range []map[string]interface{}{first, second} {
+		if resp, out := doJSON(t, app, "GET", "/tasks", "", bearer(tokens)); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("token after logout all: expected 401, got %d %v", resp.StatusCode, out)
+		}
+		if resp, out := refresh(t, app, tokens["refresh_token"]); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("refresh after logout all: expected 401, got %d %v", resp.StatusCode, out)
+		}
+	}
+
+	// Logging in again afterwards works
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", bearer(login(t, app))); resp.StatusCode != http.StatusOK {
+		t.Errorf("new login: expected 200, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestRevocationOutlastsLeeway(t *testing.T) {
+	app := newAuthTestApp(t)
+	now := time.Now()
+	exp := now.Add(-10 * time.Second)
+	token := signTestToken(t, jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": 1,
+		"jti":     "leeway",
+		"iss":     "go_taskmanagement",
+		"aud":     "go_taskmanagement",
+		"iat":     now.Add(-auth.AccessTokenTTL).Unix(),
+		"exp":     exp.Unix(),
+	})
+	headers := map[string]string{"Authorization": "Bearer " + token}
+
+	// Past exp but inside the leeway the token is still accepted...
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", headers); resp.StatusCode != http.StatusOK {
+		t.Fatalf("within leeway: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	// ...so its revocation has to last that long too
+	if err := auth.Revocations.RevokeToken("leeway", 1, time.Unix(exp.Unix(), 0)); err != nil {
+		t.Fatal(err)
+	}
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", headers); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("revoked within leeway: expected 401, got %d %v", resp.StatusCode, out)
+	}
+
+	if err := auth.Revocations.RevokeUser(1); err != nil {
+		t.Fatal(err)
+	}
+	old := signTestToken(t, jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": 1,
+		"jti":     "leeway-user",
+		"iss":     "go_taskmanagement",
+		"aud":     "go_taskmanagement",
+		"iat":     now.Add(-auth.AccessTokenTTL).Unix(),
+		"exp":     exp.Unix(),
+	})
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", map[string]string{"Authorization": "Bearer " + old}); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("user revoked within leeway: expected 401, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, claims jwt.MapClaims) string {
+	t.Helper()
+	key := interface{}([]byte("gizliAnahtar"))
+	if method == jwt.SigningMethodNone {
+		key = jwt.UnsafeAllowNoneSignatureType
+	}
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return token
+}
+
+func TestAuthMiddlewareStrictVerification(t *testing.T) {
+	app := newAuthTestApp(t)
+	now := time.Now()
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"user_id": 1,
+			"jti":     "test",
+			"iss":     "go_taskmanagement",
+			"aud":     "go_taskmanagement",
+			"iat":     now.Unix(),
+			"exp":     now.Add(time.Minute).Unix(),
+		}
+	}
+	with := func(key string, value interface{}) jwt.MapClaims {
+		claims := valid()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	cases := []struct {
+		name   string
+		token  string
+		status int
+		error  string
+	}{
+		{"valid", signTestToken(t, jwt.SigningMethodHS256, valid()), http.StatusOK, ""},
+		{"within leeway", signTestToken(t, jwt.SigningMethodHS256, with("exp", now.Add(-10*time.Second).Unix())), http.StatusOK, ""},
+		{"expired", signTestToken(t, jwt.SigningMethodHS256, with("exp", now.Add(-time.Minute).Unix())), http.StatusUnauthorized, "Token süresi dolmuş"},
+		{"alg none", signTestToken(t, jwt.SigningMethodNone, valid()), http.StatusUnauthorized, "Geçersiz token"},
+		{"other algorithm", signTestToken(t, jwt.SigningMethodHS512, valid()), http.StatusUnauthorized, "Geçersiz token"},
+		{"no exp", signTestToken(t, jwt.SigningMethodHS256, with("exp", nil)), http.StatusUnauthorized, "Geçersiz token"},
+		{"no iat", signTestToken(t, jwt.SigningMethodHS256, with("iat", nil)), http.StatusUnauthorized, "Geçersiz token"},
+		{"issued in the future", signTestToken(t, jwt.SigningMethodHS256, with("iat", now.Add(time.Minute).Unix())), http.StatusUnauthorized, "Geçersiz token"},
+		{"wrong issuer", signTestToken(t, jwt.SigningMethodHS256, with("iss", "başka")), http.StatusUnauthorized, "Geçersiz token"},
+		{"wrong audience", signTestToken(t, jwt.SigningMethodHS256, with("aud", "başka")), http.StatusUnauthorized, "Geçersiz token"},
+		{"no user", signTestToken(t, jwt.SigningMethodHS256, with("user_id", nil)), http.StatusUnauthorized, "Token kullanıcı bilgisi içermiyor"},
+	}
+	for _, tc := range cases {
+		resp, out := doJSON(t, app, "GET", "/tasks", "", map[string]string{"Authorization": "Bearer " + tc.token})
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: expected %d, got %d %v", tc.name, tc.status, resp.StatusCode, out)
+		}
+		if tc.error != "" && out["error"] != tc.error {
+			t.Errorf("%s: expected error %q, got %v", tc.name, tc.error, out["error"])
+		}
+	}
+}
+
+// useKeyring switches token signing to a fresh keyring for the test
+func useKeyring(t *testing.T, alg string) *auth.Keyring {
+	t.Helper()
+	previous := auth.DefaultConfig()
+	t.Cleanup(func() { auth.Configure(previous) })
+
+	cfg := previous
+	keys, err := auth.LoadKeyring(t.TempDir(), alg, time.Hour)
+	if err != nil {
+		t.Fatalf("load keyring: %v", err)
+	}
+	cfg.Algorithm, cfg.Keys = alg, keys
+	auth.Configure(cfg)
+	return keys
+}
+
+func tokenHeader(t *testing.T, token string) map[string]interface{} {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("parse token: %v", err)
+	}
+	return parsed.Header
+}
+
+func fetchJWKS(t *testing.T, app *fiber.App) []map[string]interface{} {
+	t.Helper()
+	resp, out := doJSON(t, app, "GET", "/.well-known/jwks.json", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("jwks: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	var keys []map[string]interface{}
+	for _, k := range out["keys"].([]interface{}) {
+		keys = append(keys, k.(map[string]interface{}))
+	}
+	return keys
+}
+
+func TestAsymmetricSigningWithRotation(t *testing.T) {
+	for _, alg := range []string{auth.AlgRS256, auth.AlgEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			app := newAuthTestApp(t)
+			app.Get("/.well-known/jwks.json", handlers.JWKSHandler)
+			keys := useKeyring(t, alg)
+
+			first := login(t, app)
+			header := tokenHeader(t, first["access_token"].(string))
+			if header["alg"] != alg || header["kid"] != keys.Active().ID {
+				t.Fatalf("unexpected token header: %v", header)
+			}
+
+			// Other services verify with the published key alone
+			jwks := fetchJWKS(t, app)
+			if len(jwks) != 1 || jwks[0]["kid"] != header["kid"] || jwks[0]["alg"] != alg {
+				t.Fatalf("unexpected jwks: %v", jwks)
+			}
+			public := keys.Active().Private.Public()
+			if _, err := jwt.Parse(first["access_token"].(string), func(*jwt.Token) (interface{}, error) { return public, nil }, jwt.WithValidMethods([]string{alg})); err != nil {
+				t.Errorf("token does not verify with the published key: %v", err)
+			}
+
+			// An HS256 token signed with the old secret is no longer accepted
+			hs := signTestToken(t, jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 1, "jti": "x", "iss": "go_taskmanagement", "aud": "go_taskmanagement", "iat": time.Now().Unix(), "exp": time.Now().Add(time.Minute).Unix()})
+			if resp, out := doJSON(t, app, "GET", "/tasks", "", map[string]string{"Authorization": "Bearer " + hs}); resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("hs256 token: expected 401, got %d %v", resp.StatusCode, out)
+			}
+
+			if _, err := keys.Rotate(); err != nil {
+				t.Fatalf("rotate: %v", err)
+			}
+			second := login(t, app)
+			if kid := tokenHeader(t, second["access_token"].(string))["kid"]; kid == header["kid"] || kid != keys.Active().ID {
+				t.Errorf("expected the new key to sign, got kid %v", kid)
+			}
+			if jwks := fetchJWKS(t, app); len(jwks) != 2 {
+				t.Errorf("expected the old and new key in jwks, got %v", jwks)
+			}
+			for _, tokens := range []map[string]interface{}{first, second} {
+				if resp, out := doJSON(t, app, "GET", "/tasks", "", bearer(tokens)); resp.StatusCode != http.StatusOK {
+					t.Errorf("token after rotation: expected 200, got %d %v", resp.StatusCode, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRotatedKeyExpiresAfterGrace(t *testing.T) {
+	app := newAuthTestApp(t)
+	app.Get("/.well-known/jwks.json", handlers.JWKSHandler)
+	previous := auth.DefaultConfig()
+	t.Cleanup(func() { auth.Configure(previous) })
+
+	keys, err := auth.LoadKeyring(t.TempDir(), auth.AlgEdDSA, 0)
+	if err != nil {
+		t.Fatalf("load keyring: %v", err)
+	}
+	cfg := previous
+	cfg.Algorithm, cfg.Keys = auth.AlgEdDSA, keys
+	auth.Configure(cfg)
+
+	old := login(t, app)
+	if _, err := keys.Rotate(); err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if resp, out := doJSON(t, app, "GET", "/tasks", "", bearer(old)); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("token of a retired key: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	if pruned, err := keys.Prune(); err != nil || pruned != 1 {
+		t.Errorf("expected 1 pruned key, got %d %v", pruned, err)
+	}
+	if jwks := fetchJWKS(t, app); len(jwks) != 1 {
+		t.Errorf("expected only the active key in jwks, got %v", jwks)
+	}
+}
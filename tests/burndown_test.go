@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go_taskmanagement/models"
+)
+
+func TestBurndown(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"API","estimate":5}`, nil)
+	doJSON(t, app, "POST", "/tasks", `{"title":"UI","estimate":3,"estimate_unit":"points"}`, nil)
+	doJSON(t, app, "POST", "/tasks", `{"title":"Dokümantasyon","estimate":2,"estimate_unit":"hours"}`, nil)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for i := range models.Tasks {
+		models.Tasks[i].CreatedAt = today.AddDate(0, 0, -3).Add(12 * time.Hour)
+	}
+	if resp, _ := doJSON(t, app, "PATCH", "/tasks/1", `{"status":"completed"}`, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("complete: expected 200, got %d", resp.StatusCode)
+	}
+	models.TaskActivities[len(models.TaskActivities)-1].CreatedAt = today.AddDate(0, 0, -1).Add(12 * time.Hour)
+	doJSON(t, app, "DELETE", "/tasks/2", "", nil)
+
+	from := today.AddDate(0, 0, -4).Format("2006-01-02")
+	resp, chart := doJSON(t, app, "GET", "/burndown?from="+from, "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("burndown: expected 200, got %d", resp.StatusCode)
+	}
+	days := chart["days"].([]interface{})
+	if len(days) != 5 {
+		t.Fatalf("expected 5 days, got %d", len(days))
+	}
+	want := [][2]float64{{0, 0}, {8, 0}, {8, 0}, {8, 5}, {5, 5}} // scope, completed
+	for i, d := range days {
+		day := d.(map[string]interface{})
+		if day["scope"] != want[i][0] || day["completed"] != want[i][1] {
+			t.Errorf("day %v: expected scope/completed %v, got %v/%v", day["date"], want[i], day["scope"], day["completed"])
+		}
+	}
+
+	if resp, _ := doJSON(t, app, "GET", "/burndown?unit=days", "", nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("bad unit: expected 400, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTaskChecklist(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"İşe alım"}`, nil)
+
+	for _, text := range []string{"Dizüstü bilgisayar", "E-posta hesabı", "Erişim yetkileri"} {
+		if resp, _ := doJSON(t, app, "POST", "/tasks/1/checklist", `{"text":"`+text+`"}`, nil); resp.StatusCode != http.StatusCreated {
+			t.Fatalf("add %q: expected 201, got %d", text, resp.StatusCode)
+		}
+	}
+	if resp, _ := doJSON(t, app, "POST", "/tasks/1/checklist", `{"text":"  "}`, nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("empty text: expected 400, got %d", resp.StatusCode)
+	}
+
+	resp, item := doJSON(t, app, "PATCH", "/tasks/1/checklist/2", `{"done":true}`, nil)
+	if resp.StatusCode != http.StatusOK || item["done"] != true || item["done_at"] == nil {
+		t.Fatalf("check: expected 200 with done_at, got %d %v", resp.StatusCode, item)
+	}
+	if _, task := doJSON(t, app, "GET", "/tasks/1", "", nil); task["checklist"].(map[string]interface{})["done"] != float64(1) || task["checklist"].(map[string]interface{})["total"] != float64(3) {
+		t.Errorf("expected 1/3 checklist counts, got %v", task["checklist"])
+	}
+
+	// Move the last item to the top, then the first item after the second
+	doJSON(t, app, "POST", "/tasks/1/checklist/3/move", `{"before_id":1}`, nil)
+	doJSON(t, app, "POST", "/tasks/1/checklist/1/move", `{"after_id":2}`, nil)
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks/1/checklist", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&items)
+	if len(items) != 3 || items[0]["id"] != float64(3) || items[1]["id"] != float64(2) || items[2]["id"] != float64(1) {
+		t.Errorf("expected order 3, 2, 1, got %v", items)
+	}
+
+	if resp, _ := doJSON(t, app, "DELETE", "/tasks/1/checklist/2", "", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("delete: expected 200, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "PATCH", "/tasks/1/checklist/2", `{"done":false}`, nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("deleted item: expected 404, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTaskCustomFields(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/projects", `{"name":"Web"}`, nil)
+	doJSON(t, app, "POST", "/projects/1/fields", `{"key":"points","name":"Puan","type":"number","required":true}`, nil)
+	doJSON(t, app, "POST", "/projects/1/fields", `{"key":"area","name":"Alan","type":"multi_select","options":["api","ui"]}`, nil)
+
+	resp, out := doJSON(t, app, "POST", "/tasks", `{"title":"A","project_id":1,"custom_fields":{"area":["db"]}}`, nil)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", resp.StatusCode)
+	}
+	fields := out["fields"].(map[string]interface{})
+	if fields["custom_fields.points"] == nil || fields["custom_fields.area"] == nil {
+		t.Errorf("expected errors for missing points and invalid area, got %v", fields)
+	}
+
+	doJSON(t, app, "POST", "/tasks", `{"title":"A","project_id":1,"custom_fields":{"points":3,"area":["api"]}}`, nil)
+	doJSON(t, app, "POST", "/tasks", `{"title":"B","project_id":1,"custom_fields":{"points":8,"area":["api","ui"]}}`, nil)
+	doJSON(t, app, "POST", "/tasks", `{"title":"C","project_id":1,"custom_fields":{"points":5,"area":["ui"]}}`, nil)
+
+	// Merge patch on custom fields only touches the given keys
+	resp, task := doJSON(t, app, "PATCH", "/tasks/3", `{"custom_fields":{"area":null}}`, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("patch: expected 200, got %d", resp.StatusCode)
+	}
+	if cf := task["custom_fields"].(map[string]interface{}); cf["points"] != float64(5) || cf["area"] != nil {
+		t.Errorf("unexpected custom fields after patch: %v", cf)
+	}
+
+	// Patching one field re-checks the stored values of the others
+	resp, task = doJSON(t, app, "PATCH", "/tasks/1", `{"custom_fields":{"points":4}}`, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("patch points: expected 200, got %d %v", resp.StatusCode, task)
+	}
+	if cf := task["custom_fields"].(map[string]interface{}); cf["points"] != float64(4) || len(cf["area"].([]interface{})) != 1 {
+		t.Errorf("unexpected custom fields after patching points: %v", cf)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks?project_id=1&cf.area=api&sort=-cf.points", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tasks []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&tasks)
+	if len(tasks) != 2 || tasks[0]["title"] != "B" || tasks[1]["title"] != "A" {
+		t.Errorf("expected B then A, got %v", tasks)
+	}
+
+	if resp, _ := doJSON(t, app, "GET", "/tasks?cf.points=3", "", nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("custom field filter without project_id: expected 400, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/handlers"
+	"go_taskmanagement/middleware"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type lockoutTestEnv struct {
+	app   *fiber.App
+	email string
+	ip    string
+	admin map[string]interface{}
+}
+
+// lockoutTestDelay is LOGIN_DELAY_BASE in these tests. Attempts are counted when they
+// start, so it has to outlast a bcrypt comparison.
+const lockoutTestDelay = 250 * time.Millisecond
+
+// newLockoutTestApp registers a fresh user and an admin. Failed login counts live for the
+// whole process, so every run uses its own address and client IP.
+func newLockoutTestApp(t *testing.T) *lockoutTestEnv {
+	t.Helper()
+	t.Setenv("ADMIN_EMAILS", "root@example.com")
+	t.Setenv("LOGIN_DELAY_BASE", lockoutTestDelay.String())
+	models.Users = []models.User{}
+	models.RefreshTokens = []models.RefreshToken{}
+	models.AuditLogs = []models.AuditLog{}
+	auth.Revocations = auth.NewRevocationStore()
+
+	app := fiber.New(fiber.Config{ProxyHeader: fiber.HeaderXForwardedFor})
+	app.Post("/register", handlers.RegisterHandler)
+	app.Post("/login", handlers.LoginHandler)
+	app.Get("/admin/lockouts", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermUsersRead), handlers.AdminLockoutsListHandler)
+	app.Delete("/admin/lockouts/:kind/:value", middleware.AuthMiddleware, middleware.RequirePermission(auth.PermUsersManage), handlers.AdminLockoutDeleteHandler)
+
+	run := accountTestRun.Add(1)
+	env := &lockoutTestEnv{
+		app:   app,
+		email: fmt.Sprintf("kilit%d@example.com", run),
+		ip:    fmt.Sprintf("10.1.%d.%d", run/250, run%250+1),
+	}
+	if resp, out := doJSON(t, app, "POST", "/register", `{"username":"kullanici","email":"`+env.email+`","password":"secret123"}`, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	registerAdmin(t, app, "root", "root@example.com")
+	env.admin = loginAs(t, app, "root@example.com")
+	return env
+}
+
+func (e *lockoutTestEnv) login(t *testing.T, email, password, ip string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	return doJSON(t, e.app, "POST", "/login", `{"email":"`+email+`","password":"`+password+`"}`, map[string]string{fiber.HeaderXForwardedFor: ip})
+}
+
+func TestLoginLockout(t *testing.T) {
+	t.Setenv("LOGIN_MAX_FAILURES", "4")
+	env := newLockoutTestApp(t)
+
+	// A successful login forgets earlier typos
+	env.login(t, env.email, "yanlis", env.ip)
+	env.login(t, env.email, "yanlis", env.ip)
+	if resp, out := env.login(t, env.email, "secret123", env.ip); resp.StatusCode != http.StatusOK {
+		t.Fatalf("login after two typos: expected 200, got %d %v", resp.StatusCode, out)
+	}
+
+	// Two free failures, then each one makes the next attempt wait
+	for i := 1; i <= 3; i++ {
+		if resp, out := env.login(t, env.email, "yanlis", env.ip); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("failure %d: expected 401, got %d %v", i, resp.StatusCode, out)
+		}
+	}
+	resp, out := env.login(t, env.email, "secret123", env.ip)
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+		t.Fatalf("attempt during delay: expected 429 with Retry-After, got %d %v", resp.StatusCode, out)
+	}
+	time.Sleep(lockoutTestDelay)
+	if resp, out := env.login(t, env.email, "yanlis", env.ip); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("failure 4: expected 401, got %d %v", resp.StatusCode, out)
+	}
+
+	// The fourth failure locks the account, even for the right password
+	time.Sleep(2 * lockoutTestDelay)
+	if resp, out := env.login(t, env.email, "secret123", env.ip); resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("locked account: expected 429, got %d %v", resp.StatusCode, out)
+	}
+	if len(models.AuditLogs) != 1 || models.AuditLogs[0].Action != models.AuditLoginLocked ||
+		models.AuditLogs[0].Subject != "account:"+env.email || models.AuditLogs[0].UserID == nil {
+		t.Fatalf("expected one lockout audit entry, got %+v", models.AuditLogs)
+	}
+
+	req := httptest.NewRequest("GET", "/admin/lockouts", nil)
+	req.Header.Set("Authorization", bearer(env.admin)["Authorization"])
+	listResp, err := env.app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var all, locks []map[string]interface{}
+	json.NewDecoder(listResp.Body).Decode(&all)
+	// Locks from other tests are still listed
+	for _, lock := range all {
+		if lock["value"] == env.email || lock["value"] == env.ip {
+			locks = append(locks, lock)
+		}
+	}
+	if len(locks) != 1 || locks[0]["kind"] != "account" || locks[0]["value"] != env.email || locks[0]["failures"] != float64(4) {
+		t.Errorf("unexpected lockouts: %v", locks)
+	}
+
+	unlock := "/admin/lockouts/account/" + env.email
+	if resp, out := doJSON(t, env.app, "DELETE", unlock, "", bearer(env.admin)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unlock: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if len(models.AuditLogs) != 2 || models.AuditLogs[1].Action != models.AuditLoginUnlocked || models.AuditLogs[1].ActorID == nil {
+		t.Errorf("expected an unlock audit entry, got %+v", models.AuditLogs)
+	}
+	if resp, out := env.login(t, env.email, "secret123", env.ip); resp.StatusCode != http.StatusOK {
+		t.Errorf("after unlock: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, env.app, "DELETE", unlock, "", bearer(env.admin)); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("second unlock: expected 404, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestLoginLockoutPerIP(t *testing.T) {
+	t.Setenv("LOGIN_MAX_FAILURES_PER_IP", "4")
+	env := newLockoutTestApp(t)
+
+	// Guessing across accounts, known or not, adds up for the IP
+	for i := 0; i < 4; i++ {
+		time.Sleep(lockoutTestDelay)
+		env.login(t, fmt.Sprintf("yok%d-%s", i, env.email), "yanlis", env.ip)
+	}
+	if resp, out := env.login(t, env.email, "secret123", env.ip); resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("locked IP: expected 429, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := env.login(t, env.email, "secret123", env.ip+"0"); resp.StatusCode != http.StatusOK {
+		t.Errorf("other IP: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if len(models.AuditLogs) != 1 || models.AuditLogs[0].Subject != "ip:"+env.ip || models.AuditLogs[0].UserID != nil {
+		t.Fatalf("expected one IP lockout audit entry, got %+v", models.AuditLogs)
+	}
+
+	if resp, out := doJSON(t, env.app, "DELETE", "/admin/lockouts/ip/"+env.ip, "", bearer(env.admin)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unlock IP: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := env.login(t, env.email, "secret123", env.ip); resp.StatusCode != http.StatusOK {
+		t.Errorf("after unlock: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, env.app, "DELETE", "/admin/lockouts/user/1", "", bearer(env.admin)); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown kind: expected 400, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestLoginLockoutConcurrentGuesses(t *testing.T) {
+	t.Setenv("LOGIN_MAX_FAILURES", "3")
+	t.Setenv("LOGIN_MAX_FAILURES_PER_IP", "100")
+	env := newLockoutTestApp(t)
+	t.Setenv("LOGIN_DELAY_BASE", "0s")
+
+	// Guesses sent at once are counted before any password is compared, so no more
+	// than the allowed number get checked
+	var wg sync.WaitGroup
+	statuses := make(chan int, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, _ := env.login(t, env.email, "yanlis", env.ip)
+			statuses <- resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+	checked := 0
+	for status := range statuses {
+		switch status {
+		case http.StatusUnauthorized:
+			checked++
+		case http.StatusTooManyRequests:
+		default:
+			t.Errorf("unexpected status %d", status)
+		}
+	}
+	if checked > 3 {
+		t.Errorf("expected at most 3 checked guesses, got %d", checked)
+	}
+	if resp, out := env.login(t, env.email, "secret123", env.ip); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("after the guesses: expected 429, got %d %v", resp.StatusCode, out)
+	}
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTaskDescriptionMarkdown(t *testing.T) {
+	app := newTaskTestApp()
+	description := "# Plan\n\n- [x] Taslak\n- [ ] Gözden geçirme\n- [ ] Yayın\n\n<script>alert(1)</script>\n\n[tıkla](javascript:alert(1)) ve [belge](https://example.com)"
+	body, _ := json.Marshal(map[string]string{"title": "Yayın planı", "description": description})
+	doJSON(t, app, "POST", "/tasks", string(body), nil)
+
+	resp, task := doJSON(t, app, "GET", "/tasks/1", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("detail: expected 200, got %d", resp.StatusCode)
+	}
+	summary := task["description_tasks"].(map[string]interface{})
+	if summary["done"] != float64(1) || summary["total"] != float64(3) {
+		t.Errorf("expected 1/3 description tasks, got %v", summary)
+	}
+	if _, ok := task["description_html"]; ok {
+		t.Errorf("description_html should only be sent with render=html")
+	}
+
+	_, task = doJSON(t, app, "GET", "/tasks/1?render=html", "", nil)
+	html, _ := task["description_html"].(string)
+	if !strings.Contains(html, "<h1>Plan</h1>") || !strings.Contains(html, `type="checkbox"`) {
+		t.Errorf("expected rendered heading and checkboxes, got %s", html)
+	}
+	if strings.Contains(html, "<script") || strings.Contains(html, "javascript:") {
+		t.Errorf("expected sanitized html, got %s", html)
+	}
+	if !strings.Contains(html, `href="https://example.com"`) || !strings.Contains(html, "nofollow") {
+		t.Errorf("expected safe link with rel nofollow, got %s", html)
+	}
+
+	if resp, _ := doJSON(t, app, "GET", "/tasks/1?render=pdf", "", nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown render: expected 400, got %d", resp.StatusCode)
+	}
+}
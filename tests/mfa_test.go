@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/handlers"
+	"go_taskmanagement/middleware"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type mfaTestEnv struct {
+	app     *fiber.App
+	email   string
+	ip      string
+	session map[string]interface{}
+}
+
+// newMFATestApp registers a user and logs them in before 2FA is turned on
+func newMFATestApp(t *testing.T) *mfaTestEnv {
+	t.Helper()
+	models.Users = []models.User{}
+	models.RefreshTokens = []models.RefreshToken{}
+	models.RecoveryCodes = []models.RecoveryCode{}
+	models.Tasks = []models.Task{}
+	auth.Revocations = auth.NewRevocationStore()
+
+	app := fiber.New(fiber.Config{ProxyHeader: fiber.HeaderXForwardedFor})
+	app.Post("/register", handlers.RegisterHandler)
+	app.Post("/login", handlers.LoginHandler)
+	app.Post("/login/mfa", handlers.LoginMFAHandler)
+	app.Get("/tasks", middleware.AuthMiddleware, handlers.TasksListHandler)
+	app.Get("/2fa", middleware.AuthMiddleware, handlers.MFAStatusHandler)
+	app.Post("/2fa/enroll", middleware.AuthMiddleware, handlers.MFAEnrollHandler)
+	app.Post("/2fa/confirm", middleware.AuthMiddleware, handlers.MFAConfirmHandler)
+	app.Post("/2fa/disable", middleware.AuthMiddleware, handlers.MFADisableHandler)
+	app.Post("/2fa/recovery-codes", middleware.AuthMiddleware, handlers.MFARecoveryCodesHandler)
+
+	// Code attempts and failed logins are counted for the whole process, so every run
+	// gets its own user ID, address and client IP
+	run := accountTestRun.Add(1)
+	env := &mfaTestEnv{
+		app:   app,
+		email: fmt.Sprintf("mehmet%d@example.com", run),
+		ip:    fmt.Sprintf("10.2.%d.%d", run/250, run%250+1),
+	}
+	if resp, out := doJSON(t, app, "POST", "/register", `{"username":"mehmet","email":"`+env.email+`","password":"secret123"}`, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	models.Users[0].ID = uint(1000 + run)
+	env.session = loginAs(t, app, env.email)
+	return env
+}
+
+// enable turns 2FA on and returns the secret and recovery codes
+func (e *mfaTestEnv) enable(t *testing.T) (string, []string) {
+	t.Helper()
+	resp, out := doJSON(t, e.app, "POST", "/2fa/enroll", "", bearer(e.session))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("enroll: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	secret := out["secret"].(string)
+	resp, out = doJSON(t, e.app, "POST", "/2fa/confirm", `{"code":"`+totp(t, secret, 0)+`"}`, bearer(e.session))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("confirm: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	var codes []string
+	for _, c := range out["recovery_codes"].([]interface{}) {
+		codes = append(codes, c.(string))
+	}
+	return secret, codes
+}
+
+// challenge logs in with the password and returns the mfa_token
+func (e *mfaTestEnv) challenge(t *testing.T) string {
+	t.Helper()
+	resp, out := doJSON(t, e.app, "POST", "/login", `{"email":"`+e.email+`","password":"secret123"}`, e.headers())
+	if resp.StatusCode != http.StatusOK || out["mfa_required"] != true || out["access_token"] != nil {
+		t.Fatalf("login: expected an MFA challenge, got %d %v", resp.StatusCode, out)
+	}
+	return out["mfa_token"].(string)
+}
+
+func (e *mfaTestEnv) loginMFA(t *testing.T, token, code string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	return doJSON(t, e.app, "POST", "/login/mfa", `{"mfa_token":"`+token+`","code":"`+code+`"}`, e.headers())
+}
+
+func (e *mfaTestEnv) headers() map[string]string {
+	return map[string]string{fiber.HeaderXForwardedFor: e.ip}
+}
+
+// totp returns the authenticator code for the given number of periods from now
+func totp(t *testing.T, secret string, periods int) string {
+	t.Helper()
+	code, err := auth.TOTPCode(secret, time.Now().Add(time.Duration(periods)*30*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestMFAEnrollAndLogin(t *testing.T) {
+	env := newMFATestApp(t)
+
+	resp, out := doJSON(t, env.app, "POST", "/2fa/enroll", "", bearer(env.session))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("enroll: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	uri := out["otpauth_uri"].(string)
+	if !strings.HasPrefix(uri, "otpauth://totp/") || !strings.Contains(uri, "secret="+out["secret"].(string)) {
+		t.Errorf("unexpected otpauth uri: %s", uri)
+	}
+	// Until confirmed, the password alone still logs in
+	loginAs(t, env.app, env.email)
+	if resp, out := doJSON(t, env.app, "POST", "/2fa/confirm", `{"code":"000000"}`, bearer(env.session)); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("wrong confirmation code: expected 422, got %d %v", resp.StatusCode, out)
+	}
+
+	secret, codes := env.enable(t)
+	if len(codes) != 10 || len(models.RecoveryCodes) != 10 || models.RecoveryCodes[0].CodeHash == codes[0] {
+		t.Fatalf("expected ten hashed recovery codes, got %v", codes)
+	}
+	if resp, out := doJSON(t, env.app, "POST", "/2fa/enroll", "", bearer(env.session)); resp.StatusCode != http.StatusConflict {
+		t.Errorf("enroll while enabled: expected 409, got %d %v", resp.StatusCode, out)
+	}
+
+	token := env.challenge(t)
+	if resp, out := doJSON(t, env.app, "GET", "/tasks", "", map[string]string{"Authorization": "Bearer " + token}); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("challenge as access token: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	// The code used for confirmation can't be used again
+	if resp, out := env.loginMFA(t, token, totp(t, secret, 0)); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("confirmation code replayed: expected 401, got %d %v", resp.StatusCode, out)
+	}
+
+	code := totp(t, secret, 1)
+	resp, out = env.loginMFA(t, token, code)
+	if resp.StatusCode != http.StatusOK || out["access_token"] == nil || out["refresh_token"] == nil {
+		t.Fatalf("second step: expected tokens, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, env.app, "GET", "/tasks", "", bearer(out)); resp.StatusCode != http.StatusOK {
+		t.Errorf("access token after 2FA: expected 200, got %d %v", resp.StatusCode, out)
+	}
+
+	// The challenge is single-use and the code can't be replayed with a new one
+	if resp, out := env.loginMFA(t, token, totp(t, secret, 0)); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("reused challenge: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := env.loginMFA(t, env.challenge(t), code); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("replayed code: expected 401, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestMFARecoveryCodes(t *testing.T) {
+	env := newMFATestApp(t)
+	secret, codes := env.enable(t)
+
+	if resp, out := env.loginMFA(t, env.challenge(t), strings.ToUpper(codes[0])); resp.StatusCode != http.StatusOK {
+		t.Fatalf("recovery code: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := env.loginMFA(t, env.challenge(t), codes[0]); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("used recovery code: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	if _, out := doJSON(t, env.app, "GET", "/2fa", "", bearer(env.session)); out["enabled"] != true || out["recovery_codes_remaining"] != float64(9) {
+		t.Errorf("unexpected status: %v", out)
+	}
+
+	// New codes replace the old set
+	resp, out := doJSON(t, env.app, "POST", "/2fa/recovery-codes", `{"code":"`+codes[1]+`"}`, bearer(env.session))
+	if resp.StatusCode != http.StatusOK || len(out["recovery_codes"].([]interface{})) != 10 {
+		t.Fatalf("regenerate: expected ten codes, got %d %v", resp.StatusCode, out)
+	}
+	if _, out := doJSON(t, env.app, "GET", "/2fa", "", bearer(env.session)); out["recovery_codes_remaining"] != float64(10) || len(models.RecoveryCodes) != 10 {
+		t.Errorf("expected only the new codes, got %v", out)
+	}
+
+	if resp, out := doJSON(t, env.app, "POST", "/2fa/disable", `{"password":"secret123","code":"`+totp(t, secret, 1)+`"}`, bearer(env.session)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("disable: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if len(models.RecoveryCodes) != 0 {
+		t.Errorf("recovery codes left after disabling: %v", models.RecoveryCodes)
+	}
+	loginAs(t, env.app, env.email)
+}
+
+func TestMFALoginAttemptsLimited(t *testing.T) {
+	t.Setenv("LOGIN_DELAY_BASE", "0s")
+	env := newMFATestApp(t)
+	secret, _ := env.enable(t)
+	token := env.challenge(t)
+
+	// Confirming used one of the five attempts
+	for i := 0; i < 4; i++ {
+		if resp, out := env.loginMFA(t, token, "000000"); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("wrong code %d: expected 401, got %d %v", i+1, resp.StatusCode, out)
+		}
+	}
+	resp, out := env.loginMFA(t, token, totp(t, secret, 1))
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("over the limit: expected 429, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestMFAFailuresCountTowardsLockout(t *testing.T) {
+	t.Setenv("LOGIN_MAX_FAILURES", "3")
+	t.Setenv("LOGIN_DELAY_BASE", "0s")
+	env := newMFATestApp(t)
+	secret, _ := env.enable(t)
+	models.AuditLogs = []models.AuditLog{}
+
+	if resp, out := doJSON(t, env.app, "POST", "/login", `{"email":"`+env.email+`","password":"yanlis"}`, env.headers()); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("wrong password: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	// The right password alone doesn't forget that failure, so two wrong codes lock
+	token := env.challenge(t)
+	for i := 0; i < 2; i++ {
+		if resp, out := env.loginMFA(t, token, "000000"); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("wrong code %d: expected 401, got %d %v", i+1, resp.StatusCode, out)
+		}
+	}
+	if len(models.AuditLogs) != 1 || models.AuditLogs[0].Action != models.AuditLoginLocked ||
+		models.AuditLogs[0].Subject != "account:"+env.email || models.AuditLogs[0].UserID == nil {
+		t.Fatalf("expected one lockout audit entry, got %+v", models.AuditLogs)
+	}
+	if resp, out := env.loginMFA(t, token, totp(t, secret, 1)); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("right code while locked: expected 429, got %d %v", resp.StatusCode, out)
+	}
+}
@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/handlers"
+	"go_taskmanagement/mail"
+	"go_taskmanagement/middleware"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// mailbox is a Mailer that hands every message to the test
+type mailbox chan mail.Message
+
+func (m mailbox) Send(msg mail.Message) error {
+	m <- msg
+	return nil
+}
+
+// expect waits for the next message sent in the background
+func (m mailbox) expect(t *testing.T) mail.Message {
+	t.Helper()
+	select {
+	case msg := <-m:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("expected an email, none was sent")
+		return mail.Message{}
+	}
+}
+
+// expectNone checks that no message arrives
+func (m mailbox) expectNone(t *testing.T) {
+	t.Helper()
+	select {
+	case msg := <-m:
+		t.Fatalf("expected no email, got %q to %s", msg.Subject, msg.To)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// The rate limiters live for the whole process, so every test run uses fresh
+// addresses and client IPs
+var accountTestRun atomic.Int64
+
+type accountTestEnv struct {
+	app          *fiber.App
+	mails        mailbox
+	email        string
+	ip           string
+	verification mail.Message // Sent on registration
+}
+
+// newAccountTestApp registers a fresh user with mails delivered to the test
+func newAccountTestApp(t *testing.T) *accountTestEnv {
+	t.Helper()
+	models.Users = []models.User{}
+	models.RefreshTokens = []models.RefreshToken{}
+	models.PasswordResets = []models.PasswordReset{}
+	models.Tasks = []models.Task{}
+	auth.Revocations = auth.NewRevocationStore()
+
+	mails := make(mailbox, 10)
+	previous := mail.Default()
+	mail.SetDefault(mails)
+	t.Cleanup(func() { mail.SetDefault(previous) })
+
+	app := fiber.New(fiber.Config{ProxyHeader: fiber.HeaderXForwardedFor})
+	app.Post("/register", handlers.RegisterHandler)
+	app.Post("/login", handlers.LoginHandler)
+	app.Post("/token/refresh", handlers.RefreshTokenHandler)
+	app.Post("/password/forgot", handlers.ForgotPasswordHandler)
+	app.Post("/password/reset", handlers.ResetPasswordHandler)
+	app.Get("/verify-email", handlers.VerifyEmailHandler)
+	app.Post("/verify-email/resend", handlers.ResendVerificationHandler)
+	app.Get("/tasks", middleware.AuthMiddleware, handlers.TasksListHandler)
+	app.Post("/tasks", middleware.AuthMiddleware, handlers.TaskCreateHandler)
+
+	run := accountTestRun.Add(1)
+	env := &accountTestEnv{
+		app:   app,
+		mails: mails,
+		email: fmt.Sprintf("kullanici%d@example.com", run),
+		ip:    fmt.Sprintf("10.0.%d.%d", run/250, run%250+1),
+	}
+	body := `{"username":"kullanici","email":"` + env.email + `","password":"secret123"}`
+	if resp, out := doJSON(t, app, "POST", "/register", body, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	env.verification = mails.expect(t)
+	return env
+}
+
+func (e *accountTestEnv) forgot(t *testing.T, email string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	return doJSON(t, e.app, "POST", "/password/forgot", `{"email":"`+email+`"}`, map[string]string{fiber.HeaderXForwardedFor: e.ip})
+}
+
+func (e *accountTestEnv) reset(t *testing.T, token, password string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	return doJSON(t, e.app, "POST", "/password/reset", `{"token":"`+token+`","password":"`+password+`"}`, nil)
+}
+
+// resetToken extracts the raw token from a reset email
+func resetToken(t *testing.T, msg mail.Message) string {
+	t.Helper()
+	_, rest, ok := strings.Cut(msg.Body, "Sıfırlama kodu: ")
+	if !ok {
+		t.Fatalf("reset email has no token: %q", msg.Body)
+	}
+	token, _, _ := strings.Cut(rest, "\n")
+	return token
+}
+
+func TestForgotPasswordDoesNotRevealAccounts(t *testing.T) {
+	env := newAccountTestApp(t)
+
+	if resp, out := env.forgot(t, "yok@example.com"); resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("unknown email: expected 202, got %d %v", resp.StatusCode, out)
+	}
+	env.mails.expectNone(t)
+
+	resp, out := env.forgot(t, env.email)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("known email: expected 202, got %d %v", resp.StatusCode, out)
+	}
+	msg := env.mails.expect(t)
+	if msg.To != env.email || !strings.Contains(msg.Body, "POST /password/reset") || strings.Contains(msg.Body, "http") {
+		t.Errorf("unexpected reset email: %+v", msg)
+	}
+	if len(models.PasswordResets) != 1 || models.PasswordResets[0].TokenHash == resetToken(t, msg) {
+		t.Errorf("expected one hashed reset token, got %v", models.PasswordResets)
+	}
+
+	if resp, out := doJSON(t, env.app, "POST", "/password/forgot", `{}`, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("missing email: expected 422, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestResetPasswordSingleUseAndRevokesSessions(t *testing.T) {
+	env := newAccountTestApp(t)
+	tokens := loginAs(t, env.app, env.email)
+
+	env.forgot(t, env.email)
+	token := resetToken(t, env.mails.expect(t))
+
+	if resp, out := env.reset(t, token, "kisa"); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("short password: expected 422, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := env.reset(t, token, strings.Repeat("ş", 37)); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("password over 72 bytes: expected 422, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := env.reset(t, token, "yeniSifre123"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("reset: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := env.reset(t, token, "baskaSifre123"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("reused token: expected 400, got %d %v", resp.StatusCode, out)
+	}
+
+	// Sessions opened with the old password are gone
+	if resp, out := doJSON(t, env.app, "GET", "/tasks", "", bearer(tokens)); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("old access token: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := refresh(t, env.app, tokens["refresh_token"]); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("old refresh token: expected 401, got %d %v", resp.StatusCode, out)
+	}
+
+	if resp, out := doJSON(t, env.app, "POST", "/login", `{"email":"`+env.email+`","password":"secret123"}`, nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("old password: expected 401, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := doJSON(t, env.app, "POST", "/login", `{"email":"`+env.email+`","password":"yeniSifre123"}`, nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("new password: expected 200, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestResetPasswordClearsAccountLockout(t *testing.T) {
+	t.Setenv("LOGIN_MAX_FAILURES", "2")
+	t.Setenv("LOGIN_DELAY_BASE", "0s")
+	env := newAccountTestApp(t)
+	login := func(password string) *http.Response {
+		resp, _ := doJSON(t, env.app, "POST", "/login", `{"email":"`+env.email+`","password":"`+password+`"}`, map[string]string{fiber.HeaderXForwardedFor: env.ip})
+		return resp
+	}
+
+	login("yanlis")
+	login("yanlis")
+	if resp := login("secret123"); resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("locked account: expected 429, got %d", resp.StatusCode)
+	}
+
+	env.forgot(t, env.email)
+	if resp, out := env.reset(t, resetToken(t, env.mails.expect(t)), "yeniSifre123"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("reset: expected 200, got %d %v", resp.StatusCode, out)
+	}
+	if resp := login("yeniSifre123"); resp.StatusCode != http.StatusOK {
+		t.Errorf("after reset: expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestResetPasswordOnlyLatestTokenCounts(t *testing.T) {
+	env := newAccountTestApp(t)
+	env.forgot(t, env.email)
+	first := resetToken(t, env.mails.expect(t))
+	env.forgot(t, env.email)
+	second := resetToken(t, env.mails.expect(t))
+
+	if resp, out := env.reset(t, first, "yeniSifre123"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("superseded token: expected 400, got %d %v", resp.StatusCode, out)
+	}
+	if resp, out := env.reset(t, second, "yeniSifre123"); resp.StatusCode != http.StatusOK {
+		t.Errorf("latest token: expected 200, got %d %v", resp.StatusCode, out)
+	}
+}
+
+func TestForgotPasswordRateLimits(t *testing.T) {
+	env := newAccountTestApp(t)
+
+	// At most three emails per address, further requests still look accepted
+	for i := 0; i < 5; i++ {
+		if resp, out := env.forgot(t, env.email); resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("request %d: expected 202, got %d %v", i+1, resp.StatusCode, out)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		env.mails.expect(t)
+	}
+	env.mails.expectNone(t)
+
+	// Ten requests per IP, whatever the address
+	for i := 5; i < 10; i++ {
+		env.forgot(t, fmt.Sprintf("yok%d@example.com", i))
+	}
+	resp, out := env.forgot(t, "yok@example.com")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("over IP limit: expected 429, got %d %v", resp.StatusCode, out)
+	}
+	if resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+		t.Error("expected Retry-After on 429")
+	}
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+const reviewWorkflow = `{"statuses":["todo","review","done"],"done":["done"],
+	"transitions":{"todo":["review"],"review":["todo","done"]},"reopen":{"done":["todo"]}}`
+
+func TestProjectWorkflow(t *testing.T) {
+	app := newTaskTestApp()
+
+	resp, project := doJSON(t, app, "POST", "/projects", `{"name":"Web","workflow":`+reviewWorkflow+`}`, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d", resp.StatusCode)
+	}
+	if project["id"] != float64(1) {
+		t.Fatalf("unexpected project %v", project)
+	}
+
+	resp, task := doJSON(t, app, "POST", "/tasks", `{"title":"Tasarım","project_id":1}`, nil)
+	if resp.StatusCode != http.StatusCreated || task["status"] != "todo" {
+		t.Fatalf("expected task in initial project status todo, got %d %v", resp.StatusCode, task["status"])
+	}
+
+	// The project's transitions apply, not the default workflow
+	if resp, _ := doJSON(t, app, "PUT", "/tasks/1", `{"title":"Tasarım","status":"done","priority":"low"}`, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("todo -> done: expected 422, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "PUT", "/tasks/1", `{"title":"Tasarım","status":"review","priority":"low"}`, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("todo -> review: expected 200, got %d", resp.StatusCode)
+	}
+
+	if resp, _ := doJSON(t, app, "POST", "/tasks", `{"title":"Test","project_id":1}`, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create second task: expected 201, got %d", resp.StatusCode)
+	}
+
+	// Removing review needs a target for the task still in it
+	noReview := `{"statuses":["todo","done"],"done":["done"],"transitions":{"todo":["done"]}}`
+	resp, out := doJSON(t, app, "PUT", "/projects/1/workflow", `{"workflow":`+noReview+`}`, nil)
+	if resp.StatusCode != http.StatusUnprocessableEntity || out["fields"].(map[string]interface{})["status_map.review"] == nil {
+		t.Fatalf("unmapped status: expected 422 for status_map.review, got %d %v", resp.StatusCode, out)
+	}
+
+	body := `{"workflow":` + noReview + `,"status_map":{"review":"todo"}}`
+	resp, out = doJSON(t, app, "PUT", "/projects/1/workflow?dry_run=true", body, nil)
+	if resp.StatusCode != http.StatusOK || out["migrated"].(map[string]interface{})["review"] != float64(1) {
+		t.Fatalf("dry run: expected 1 task to migrate, got %d %v", resp.StatusCode, out)
+	}
+	if _, task := doJSON(t, app, "GET", "/tasks/1", "", nil); task["status"] != "review" {
+		t.Fatalf("dry run must not move tasks, got %v", task["status"])
+	}
+
+	if resp, _ := doJSON(t, app, "PUT", "/projects/1/workflow", body, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("migrate: expected 200, got %d", resp.StatusCode)
+	}
+	_, moved := doJSON(t, app, "GET", "/tasks/1", "", nil)
+	if moved["status"] != "todo" {
+		t.Errorf("expected task moved to todo, got %v", moved["status"])
+	}
+	// The moved task goes after the one already in todo
+	if _, stayed := doJSON(t, app, "GET", "/tasks/2", "", nil); moved["rank"].(string) <= stayed["rank"].(string) {
+		t.Errorf("expected moved task ranked after %q, got %q", stayed["rank"], moved["rank"])
+	}
+}
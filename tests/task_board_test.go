@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// columnOrder returns the ids of the tasks in the given status, in list order
+func columnOrder(tasks []map[string]interface{}, status string) []float64 {
+	var ids []float64
+	for _, task := range tasks {
+		if task["status"] == status {
+			ids = append(ids, task["id"].(float64))
+		}
+	}
+	return ids
+}
+
+func TestTaskBoardMove(t *testing.T) {
+	app := newTaskTestApp()
+	for i := 1; i <= 4; i++ {
+		doJSON(t, app, "POST", "/tasks", fmt.Sprintf(`{"title":"Kart %d"}`, i), nil)
+	}
+
+	// Reorder within the column: 4 goes to the top, 1 after 3
+	if resp, _ := doJSON(t, app, "POST", "/tasks/4/move", `{"before_id":1}`, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("move before: expected 200, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "POST", "/tasks/1/move", `{"after_id":3}`, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("move after: expected 200, got %d", resp.StatusCode)
+	}
+
+	// Move to another column together with the status change
+	resp, moved := doJSON(t, app, "POST", "/tasks/2/move", `{"status":"in_progress"}`, nil)
+	if resp.StatusCode != http.StatusOK || moved["status"] != "in_progress" {
+		t.Fatalf("move column: expected 200 in_progress, got %d %v", resp.StatusCode, moved)
+	}
+	doJSON(t, app, "PATCH", "/tasks/3", `{"status":"in_progress"}`, nil)
+	doJSON(t, app, "POST", "/tasks/3/move", `{"before_id":2}`, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tasks []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&tasks)
+	if got := fmt.Sprint(columnOrder(tasks, "pending")); got != "[4 1]" {
+		t.Errorf("pending column: expected [4 1], got %s", got)
+	}
+	if got := fmt.Sprint(columnOrder(tasks, "in_progress")); got != "[3 2]" {
+		t.Errorf("in_progress column: expected [3 2], got %s", got)
+	}
+
+	// The anchor has to be in the target column
+	if resp, _ := doJSON(t, app, "POST", "/tasks/4/move", `{"after_id":2}`, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("foreign anchor: expected 422, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "POST", "/tasks/4/move", `{"status":"unknown"}`, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("unknown status: expected 422, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"go_taskmanagement/models"
+)
+
+func TestTaskBulkAtomicRollsBack(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, nil)
+
+	body := `{"mode":"atomic","operations":[
+		{"op":"update","id":1,"patch":{"status":"completed"}},
+		{"op":"delete","id":99}
+	]}`
+	resp, out := doJSON(t, app, "POST", "/tasks/bulk", body, nil)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", resp.StatusCode)
+	}
+	results := out["results"].([]interface{})
+	if got := results[0].(map[string]interface{})["status"]; got != float64(http.StatusFailedDependency) {
+		t.Errorf("rolled back item: expected 424, got %v", got)
+	}
+	if got := results[1].(map[string]interface{})["status"]; got != float64(http.StatusNotFound) {
+		t.Errorf("missing task: expected 404, got %v", got)
+	}
+	if models.Tasks[0].Status != "pending" {
+		t.Errorf("atomic failure must not change tasks, status is %q", models.Tasks[0].Status)
+	}
+}
+
+func TestTaskBulkPartialFilter(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"A","priority":"low"}`, nil)
+	doJSON(t, app, "POST", "/tasks", `{"title":"B","priority":"low"}`, nil)
+	doJSON(t, app, "POST", "/tasks", `{"title":"C","priority":"high"}`, nil)
+
+	body := `{"mode":"partial","filter":{"priority":"low"},"patch":{"status":"completed"}}`
+	resp, out := doJSON(t, app, "POST", "/tasks/bulk", body, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if out["succeeded"] != float64(2) {
+		t.Errorf("expected 2 updated tasks, got %v", out["succeeded"])
+	}
+	if models.Tasks[2].Status != "pending" {
+		t.Errorf("task outside the filter was changed")
+	}
+}
+
+func TestTaskBulkEmptyFilterNeedsAll(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"A"}`, nil)
+	doJSON(t, app, "POST", "/tasks", `{"title":"B"}`, nil)
+
+	resp, out := doJSON(t, app, "POST", "/tasks/bulk", `{"filter":{},"patch":{"priority":"high"}}`, nil)
+	if resp.StatusCode != http.StatusUnprocessableEntity || out["fields"] == nil {
+		t.Fatalf("empty filter: expected 422 with field errors, got %d %v", resp.StatusCode, out)
+	}
+	if models.Tasks[0].Priority == "high" {
+		t.Errorf("empty filter changed a task")
+	}
+
+	resp, out = doJSON(t, app, "POST", "/tasks/bulk", `{"filter":{"all":true},"patch":{"priority":"high"}}`, nil)
+	if resp.StatusCode != http.StatusOK || out["succeeded"] != float64(2) {
+		t.Errorf("all: expected 200 with 2 updated tasks, got %d %v", resp.StatusCode, out)
+	}
+}
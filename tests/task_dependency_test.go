@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"go_taskmanagement/models"
+)
+
+func TestTaskDependencies(t *testing.T) {
+	app := newTaskTestApp()
+	for _, title := range []string{"A", "B", "C"} {
+		doJSON(t, app, "POST", "/tasks", `{"title":"`+title+`"}`, nil)
+	}
+
+	// A blocks B, B blocks C
+	if resp, _ := doJSON(t, app, "POST", "/tasks/2/dependencies", `{"blocker_id":1}`, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("add dependency: expected 201, got %d", resp.StatusCode)
+	}
+	doJSON(t, app, "POST", "/tasks/3/dependencies", `{"blocker_id":2}`, nil)
+
+	// C blocks A would close the loop A -> B -> C -> A
+	if resp, _ := doJSON(t, app, "POST", "/tasks/1/dependencies", `{"blocker_id":3}`, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("cycle: expected 422, got %d", resp.StatusCode)
+	}
+
+	if _, task := doJSON(t, app, "GET", "/tasks/2", "", nil); task["blocked"] != true {
+		t.Errorf("expected B to be blocked, got %v", task["blocked"])
+	}
+	if resp, _ := doJSON(t, app, "PATCH", "/tasks/2", `{"status":"completed"}`, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("completing blocked task: expected 422, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "PATCH", "/tasks/2?force=true", `{"status":"completed"}`, nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("forced completion: expected 200, got %d", resp.StatusCode)
+	}
+
+	// Completing the blocker unblocks C
+	if _, task := doJSON(t, app, "GET", "/tasks/3", "", nil); task["blocked"] != false {
+		t.Errorf("expected C to be unblocked once B is done, got %v", task["blocked"])
+	}
+
+	if resp, _ := doJSON(t, app, "DELETE", "/tasks/2/dependencies/1", "", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("remove dependency: expected 200, got %d", resp.StatusCode)
+	}
+
+	// A removal must not let a new dependency reuse a live id
+	if resp, _ := doJSON(t, app, "POST", "/tasks/3/dependencies", `{"blocker_id":1}`, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("add after removal: expected 201, got %d", resp.StatusCode)
+	}
+	seen := map[uint]bool{}
+	for _, d := range models.TaskDependencies {
+		if seen[d.ID] {
+			t.Errorf("duplicate dependency id %d in %v", d.ID, models.TaskDependencies)
+		}
+		seen[d.ID] = true
+	}
+}
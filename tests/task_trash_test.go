@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go_taskmanagement/models"
+)
+
+func TestTaskTrashRestoreAndPurge(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, nil)
+	doJSON(t, app, "DELETE", "/tasks/1", "", nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks/trash", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var trashed []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&trashed)
+	if len(trashed) != 1 || trashed[0]["deleted_at"] == nil {
+		t.Fatalf("expected one trashed task with deleted_at, got %v", trashed)
+	}
+
+	if resp, _ := doJSON(t, app, "POST", "/tasks/1/restore", "", nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("restore: expected 200, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "GET", "/tasks/1", "", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("restored task: expected 200, got %d", resp.StatusCode)
+	}
+
+	doJSON(t, app, "DELETE", "/tasks/1?permanent=true", "", nil)
+	if resp, _ := doJSON(t, app, "POST", "/tasks/1/restore", "", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("purged task: expected 404 on restore, got %d", resp.StatusCode)
+	}
+}
+
+func TestTaskPurgeKeepsTimeEntries(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, nil)
+	doJSON(t, app, "POST", "/tasks/1/time-entries", `{"started_at":"`+time.Now().Add(-2*time.Hour).UTC().Format(time.RFC3339)+`","duration_seconds":600}`, nil)
+	if resp, out := doJSON(t, app, "POST", "/tasks/1/timer/start", "", nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("start timer: expected 201, got %d %v", resp.StatusCode, out)
+	}
+
+	doJSON(t, app, "DELETE", "/tasks/1?permanent=true", "", nil)
+
+	// The timer on the purged task is stopped, its entries stay in the report
+	if resp, _ := doJSON(t, app, "POST", "/timer/stop", "", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("stop after purge: expected 404, got %d", resp.StatusCode)
+	}
+	_, report := doJSON(t, app, "GET", "/time-entries", "", nil)
+	if groups := report["groups"].([]interface{}); len(groups) != 1 || groups[0].(map[string]interface{})["entries"] != float64(2) {
+		t.Errorf("expected both entries of the purged task in the report, got %v", report["groups"])
+	}
+	if last := models.TaskActivities[len(models.TaskActivities)-1]; last.TaskID != 1 || last.Action != models.ActivityPurged {
+		t.Errorf("expected the purge in the task history, got %+v", last)
+	}
+}
+
+func TestTaskActivityHistory(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, nil)
+	doJSON(t, app, "PATCH", "/tasks/1", `{"status":"in_progress","priority":"high"}`, nil)
+	doJSON(t, app, "DELETE", "/tasks/1", "", nil)
+
+	resp, page := doJSON(t, app, "GET", "/tasks/1/activity?limit=2", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	// created + two field updates + deleted
+	if page["total"] != float64(4) {
+		t.Fatalf("expected 4 entries, got %v", page["total"])
+	}
+	items := page["items"].([]interface{})
+	if len(items) != 2 || items[0].(map[string]interface{})["action"] != "deleted" {
+		t.Errorf("expected newest-first page of 2 starting with deleted, got %v", items)
+	}
+}
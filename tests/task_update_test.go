@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go_taskmanagement/handlers"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newTaskTestApp wires the task handlers behind a stub auth that always acts as user 1
+func newTaskTestApp() *fiber.App {
+	models.Tasks = []models.Task{}
+	models.TaskActivities = []models.TaskActivity{}
+	models.Projects = []models.Project{}
+	models.CustomFields = []models.CustomField{}
+	models.TaskDependencies = []models.TaskDependency{}
+	models.TimeEntries = []models.TimeEntry{}
+	models.ChecklistItems = []models.ChecklistItem{}
+	models.TaskTemplates = []models.TaskTemplate{}
+
+	app := fiber.New()
+	auth := func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		return c.Next()
+	}
+	app.Get("/tasks", auth, handlers.TasksListHandler)
+	app.Post("/tasks", auth, handlers.TaskCreateHandler)
+	app.Post("/tasks/bulk", auth, handlers.TaskBulkHandler)
+	app.Get("/tasks/trash", auth, handlers.TrashListHandler)
+	app.Post("/tasks/:id/restore", auth, handlers.TaskRestoreHandler)
+	app.Post("/tasks/:id/reopen", auth, handlers.TaskReopenHandler)
+	app.Post("/tasks/:id/move", auth, handlers.TaskMoveHandler)
+	app.Get("/tasks/:id/activity", auth, handlers.TaskActivityHandler)
+	app.Get("/tasks/:id/checklist", auth, handlers.TaskChecklistHandler)
+	app.Post("/tasks/:id/checklist", auth, handlers.ChecklistItemCreateHandler)
+	app.Patch("/tasks/:id/checklist/:item_id", auth, handlers.ChecklistItemUpdateHandler)
+	app.Delete("/tasks/:id/checklist/:item_id", auth, handlers.ChecklistItemDeleteHandler)
+	app.Post("/tasks/:id/checklist/:item_id/move", auth, handlers.ChecklistItemMoveHandler)
+	app.Post("/tasks/:id/dependencies", auth, handlers.TaskDependencyAddHandler)
+	app.Delete("/tasks/:id/dependencies/:blocker_id", auth, handlers.TaskDependencyRemoveHandler)
+	app.Get("/tasks/:id", auth, handlers.TaskDetailHandler)
+	app.Put("/tasks/:id", auth, handlers.TaskUpdateHandler)
+	app.Patch("/tasks/:id", auth, handlers.TaskPatchHandler)
+	app.Delete("/tasks/:id", auth, handlers.TaskDeleteHandler)
+	app.Post("/tasks/:id/timer/start", auth, handlers.TimerStartHandler)
+	app.Post("/tasks/:id/time-entries", auth, handlers.TimeEntryCreateHandler)
+	app.Post("/timer/stop", auth, handlers.TimerStopHandler)
+	app.Get("/time-entries", auth, handlers.TimeReportHandler)
+	app.Post("/templates", auth, handlers.TemplateCreateHandler)
+	app.Post("/templates/:id/instantiate", auth, handlers.TemplateInstantiateHandler)
+	app.Get("/burndown", auth, handlers.BurndownHandler)
+	app.Post("/projects", auth, handlers.ProjectCreateHandler)
+	app.Put("/projects/:id/workflow", auth, handlers.ProjectWorkflowUpdateHandler)
+	app.Post("/projects/:id/fields", auth, handlers.CustomFieldCreateHandler)
+	return app
+}
+
+func doJSON(t *testing.T, app *fiber.App, method, url, body string, headers map[string]string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req, 1000)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	var out map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&out)
+	return resp, out
+}
+
+func TestTaskMergePatch(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"Rapor","description":"Taslak","priority":"high"}`, nil)
+
+	// Absent fields stay, null clears description and resets priority
+	resp, task := doJSON(t, app, "PATCH", "/tasks/1", `{"description":null,"priority":null}`, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if task["title"] != "Rapor" || task["description"] != "" || task["priority"] != "medium" {
+		t.Errorf("unexpected task after patch: %v", task)
+	}
+
+	if resp, _ := doJSON(t, app, "PATCH", "/tasks/1", `{"title":null}`, nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("null title: expected 400, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "PUT", "/tasks/1", `{"title":"Yeni"}`, nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("PUT without required fields: expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestTaskConditionalRequests(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, nil)
+
+	resp, _ := doJSON(t, app, "GET", "/tasks/1", "", nil)
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag header on task detail")
+	}
+	if resp, _ := doJSON(t, app, "GET", "/tasks/1", "", map[string]string{"If-None-Match": etag}); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("If-None-Match: expected 304, got %d", resp.StatusCode)
+	}
+
+	body := `{"title":"Rapor","status":"in_progress","priority":"high"}`
+	if resp, _ := doJSON(t, app, "PUT", "/tasks/1", body, map[string]string{"If-Match": etag}); resp.StatusCode != http.StatusOK {
+		t.Fatalf("If-Match current: expected 200, got %d", resp.StatusCode)
+	}
+	// The first write bumped the version, so the old tag is stale now
+	if resp, _ := doJSON(t, app, "PUT", "/tasks/1", body, map[string]string{"If-Match": etag}); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("If-Match stale: expected 412, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "DELETE", "/tasks/1", "", map[string]string{"If-Match": etag}); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("DELETE with stale If-Match: expected 412, got %d", resp.StatusCode)
+	}
+}
+
+func TestTaskETagCoversComputedFields(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, nil)
+
+	resp, _ := doJSON(t, app, "GET", "/tasks/1", "", nil)
+	etag := resp.Header.Get("ETag")
+
+	// A checklist item changes the task's representation without a new version
+	doJSON(t, app, "POST", "/tasks/1/checklist", `{"text":"Taslak"}`, nil)
+	resp, task := doJSON(t, app, "GET", "/tasks/1", "", map[string]string{"If-None-Match": etag})
+	if resp.StatusCode != http.StatusOK || task["checklist"].(map[string]interface{})["total"] != float64(1) {
+		t.Fatalf("If-None-Match after checklist change: expected 200 with the new count, got %d %v", resp.StatusCode, task["checklist"])
+	}
+	if resp.Header.Get("ETag") == etag {
+		t.Errorf("expected a new ETag, got %s again", etag)
+	}
+
+	body := `{"title":"Rapor","status":"in_progress","priority":"high"}`
+	if resp, _ := doJSON(t, app, "PUT", "/tasks/1", body, map[string]string{"If-Match": etag}); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("If-Match before checklist change: expected 412, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "PUT", "/tasks/1", body, map[string]string{"If-Match": resp.Header.Get("ETag")}); resp.StatusCode != http.StatusOK {
+		t.Errorf("If-Match current: expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestTaskStatusWorkflow(t *testing.T) {
+	app := newTaskTestApp()
+
+	resp, out := doJSON(t, app, "POST", "/tasks", `{"title":"Rapor","status":"done","priority":"urgent"}`, nil)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("invalid enums: expected 422, got %d", resp.StatusCode)
+	}
+	fields, _ := out["fields"].(map[string]interface{})
+	if fields["status"] == nil || fields["priority"] == nil {
+		t.Errorf("expected field errors for status and priority, got %v", out)
+	}
+
+	doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, nil)
+	doJSON(t, app, "PATCH", "/tasks/1", `{"status":"completed"}`, nil)
+
+	// Going back from completed needs an explicit reopen
+	if resp, _ := doJSON(t, app, "PATCH", "/tasks/1", `{"status":"pending"}`, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("completed -> pending via PATCH: expected 422, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "PATCH", "/tasks/1", `{"status":null}`, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("null status on completed task: expected 422, got %d", resp.StatusCode)
+	}
+	resp, task := doJSON(t, app, "POST", "/tasks/1/reopen", "", nil)
+	if resp.StatusCode != http.StatusOK || task["status"] != "pending" {
+		t.Errorf("reopen: expected 200 with pending, got %d %v", resp.StatusCode, task["status"])
+	}
+}
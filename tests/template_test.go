@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"go_taskmanagement/models"
+)
+
+func TestTemplateInstantiate(t *testing.T) {
+	app := newTaskTestApp()
+	body := `{
+		"name": "Yeni çalışan",
+		"task": {
+			"title": "{{name}} için işe alım",
+			"priority": "high",
+			"tags": ["onboarding", " onboarding "],
+			"checklist": ["{{name}} için bilgisayar", "E-posta hesabı"],
+			"due_in_days": 5,
+			"subtasks": [
+				{"title": "{{name}} ile tanışma toplantısı", "due_in_days": 1},
+				{"title": "Erişimleri aç", "checklist": ["Depo erişimi"]}
+			]
+		}
+	}`
+	resp, tmpl := doJSON(t, app, "POST", "/templates", body, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d %v", resp.StatusCode, tmpl)
+	}
+	if vars := tmpl["variables"].([]interface{}); len(vars) != 1 || vars[0] != "name" {
+		t.Errorf("expected variables [name], got %v", vars)
+	}
+
+	if resp, out := doJSON(t, app, "POST", "/templates/1/instantiate", `{}`, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("missing variable: expected 422, got %d %v", resp.StatusCode, out)
+	}
+	if len(models.Tasks) != 0 {
+		t.Fatalf("expected no tasks after a failed instantiate, got %d", len(models.Tasks))
+	}
+
+	resp, out := doJSON(t, app, "POST", "/templates/1/instantiate", `{"variables":{"name":"Ayşe"},"start_date":"2025-09-01"}`, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("instantiate: expected 201, got %d %v", resp.StatusCode, out)
+	}
+	tasks := out["tasks"].([]interface{})
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(tasks))
+	}
+	root := tasks[0].(map[string]interface{})
+	if root["title"] != "Ayşe için işe alım" || root["due_date"] != "2025-09-06T00:00:00Z" || root["priority"] != "high" {
+		t.Errorf("unexpected root task: %v", root)
+	}
+	if tags := root["tags"].([]interface{}); len(tags) != 1 {
+		t.Errorf("expected de-duplicated tags, got %v", tags)
+	}
+	if root["checklist"].(map[string]interface{})["total"] != float64(2) {
+		t.Errorf("expected 2 checklist items on the root, got %v", root["checklist"])
+	}
+	sub := tasks[1].(map[string]interface{})
+	if sub["title"] != "Ayşe ile tanışma toplantısı" || sub["parent_id"] != root["id"] {
+		t.Errorf("unexpected subtask: %v", sub)
+	}
+}
@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestTimeTracking(t *testing.T) {
+	app := newTaskTestApp()
+	doJSON(t, app, "POST", "/tasks", `{"title":"Danışmanlık"}`, nil)
+	doJSON(t, app, "POST", "/tasks", `{"title":"Rapor"}`, nil)
+
+	if resp, _ := doJSON(t, app, "POST", "/tasks/1/timer/start", "", nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("start: expected 201, got %d", resp.StatusCode)
+	}
+	if resp, _ := doJSON(t, app, "POST", "/tasks/2/timer/start", "", nil); resp.StatusCode != http.StatusConflict {
+		t.Errorf("second timer: expected 409, got %d", resp.StatusCode)
+	}
+	if resp, entry := doJSON(t, app, "POST", "/timer/stop", "", nil); resp.StatusCode != http.StatusOK || entry["ended_at"] == nil {
+		t.Errorf("stop: expected 200 with ended_at, got %d %v", resp.StatusCode, entry)
+	}
+
+	body := `{"started_at":"2025-08-25T09:00:00Z","ended_at":"2025-08-25T10:30:00Z","note":"Analiz"}`
+	if resp, _ := doJSON(t, app, "POST", "/tasks/1/time-entries", body, nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("manual entry: expected 201, got %d", resp.StatusCode)
+	}
+	doJSON(t, app, "POST", "/tasks/2/time-entries", `{"started_at":"2025-08-26T09:00:00Z","duration_seconds":1800}`, nil)
+
+	if _, task := doJSON(t, app, "GET", "/tasks/1", "", nil); task["tracked_seconds"].(float64) < 5400 {
+		t.Errorf("expected at least 5400 tracked seconds, got %v", task["tracked_seconds"])
+	}
+
+	resp, report := doJSON(t, app, "GET", "/time-entries?from=2025-08-25&to=2025-08-26&group_by=day", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("report: expected 200, got %d", resp.StatusCode)
+	}
+	groups := report["groups"].([]interface{})
+	if report["total_seconds"] != float64(7200) || len(groups) != 2 || groups[0].(map[string]interface{})["key"] != "2025-08-25" {
+		t.Errorf("unexpected day report: %v", report)
+	}
+}
@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go_taskmanagement/auth"
+	"go_taskmanagement/handlers"
+	"go_taskmanagement/mail"
+	"go_taskmanagement/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// verificationLink extracts the /verify-email path and query from a verification email
+func verificationLink(t *testing.T, msg mail.Message) string {
+	t.Helper()
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if u, err := url.Parse(line); err == nil && u.Path == "/verify-email" {
+			return u.RequestURI()
+		}
+	}
+	t.Fatalf("verification email has no link: %q", msg.Body)
+	return ""
+}
+
+func TestVerifyEmail(t *testing.T) {
+	env := newAccountTestApp(t)
+	if env.verification.To != env.email {
+		t.Fatalf("verification sent to %s, expected %s", env.verification.To, env.email)
+	}
+	link := verificationLink(t, env.verification)
+
+	resp, out := doJSON(t, env.app, "GET", link, "", nil)
+	if resp.StatusCode != http.StatusOK || out["email_verified_at"] == nil {
+		t.Fatalf("verify: expected 200 with email_verified_at, got %d %v", resp.StatusCode, out)
+	}
+	first := out["email_verified_at"]
+
+	// Opening the link again keeps the original time
+	resp, out = doJSON(t, env.app, "GET", link, "", nil)
+	if resp.StatusCode != http.StatusOK || out["email_verified_at"] != first {
+		t.Errorf("second click: expected 200 with the same time, got %d %v", resp.StatusCode, out)
+	}
+
+	// Resending to a verified address sends nothing
+	doJSON(t, env.app, "POST", "/verify-email/resend", `{"email":"`+env.email+`"}`, map[string]string{fiber.HeaderXForwardedFor: env.ip})
+	env.mails.expectNone(t)
+}
+
+func TestVerifyEmailRejectsTamperedLinks(t *testing.T) {
+	env := newAccountTestApp(t)
+	link := verificationLink(t, env.verification)
+	token, _ := url.ParseQuery(strings.SplitN(link, "?", 2)[1])
+	raw := token.Get("token")
+
+	for name, bad := range map[string]string{
+		"missing":  "/verify-email",
+		"garbage":  "/verify-email?token=abc",
+		"tampered": "/verify-email?token=" + url.QueryEscape("eA"+raw[2:]),
+	} {
+		if resp, out := doJSON(t, env.app, "GET", bad, "", nil); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s token: expected 400, got %d %v", name, resp.StatusCode, out)
+		}
+	}
+}
+
+func TestEmailVerificationSwitch(t *testing.T) {
+	t.Run("login", func(t *testing.T) {
+		t.Setenv("EMAIL_VERIFICATION", "login")
+		env := newAccountTestApp(t)
+		login := `{"email":"` + env.email + `","password":"secret123"}`
+
+		if resp, out := doJSON(t, env.app, "POST", "/login", login, nil); resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("unverified login: expected 403, got %d %v", resp.StatusCode, out)
+		}
+		// A wrong password still looks like any other failed login
+		if resp, out := doJSON(t, env.app, "POST", "/login", `{"email":"`+env.email+`","password":"yanlis"}`, nil); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("wrong password: expected 401, got %d %v", resp.StatusCode, out)
+		}
+
+		doJSON(t, env.app, "GET", verificationLink(t, env.verification), "", nil)
+		if resp, out := doJSON(t, env.app, "POST", "/login", login, nil); resp.StatusCode != http.StatusOK {
+			t.Errorf("verified login: expected 200, got %d %v", resp.StatusCode, out)
+		}
+	})
+
+	t.Run("tasks", func(t *testing.T) {
+		t.Setenv("EMAIL_VERIFICATION", "tasks")
+		env := newAccountTestApp(t)
+		tokens := loginAs(t, env.app, env.email)
+
+		if resp, out := doJSON(t, env.app, "GET", "/tasks", "", bearer(tokens)); resp.StatusCode != http.StatusOK {
+			t.Errorf("unverified list: expected 200, got %d %v", resp.StatusCode, out)
+		}
+		if resp, out := doJSON(t, env.app, "POST", "/tasks", `{"title":"Rapor"}`, bearer(tokens)); resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("unverified create: expected 403, got %d %v", resp.StatusCode, out)
+		}
+
+		doJSON(t, env.app, "GET", verificationLink(t, env.verification), "", nil)
+		if resp, out := doJSON(t, env.app, "POST", "/tasks", `{"title":"Rapor"}`, bearer(tokens)); resp.StatusCode != http.StatusCreated {
+			t.Errorf("verified create: expected 201, got %d %v", resp.StatusCode, out)
+		}
+	})
+}
+
+func TestResendVerificationRateLimits(t *testing.T) {
+	env := newAccountTestApp(t)
+	resend := func(email string) *http.Response {
+		resp, _ := doJSON(t, env.app, "POST", "/verify-email/resend", `{"email":"`+email+`"}`, map[string]string{fiber.HeaderXForwardedFor: env.ip})
+		return resp
+	}
+
+	for i := 0; i < 5; i++ {
+		if resp := resend(env.email); resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("resend %d: expected 202, got %d", i+1, resp.StatusCode)
+		}
+	}
+	msgs := []mail.Message{env.mails.expect(t), env.mails.expect(t), env.mails.expect(t)}
+	env.mails.expectNone(t)
+
+	// Every resent link works
+	if resp, out := doJSON(t, env.app, "GET", verificationLink(t, msgs[2]), "", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("resent link: expected 200, got %d %v", resp.StatusCode, out)
+	}
+
+	for i := 5; i < 10; i++ {
+		resend("yok@example.com")
+	}
+	if resp := resend("yok@example.com"); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("over IP limit: expected 429, got %d", resp.StatusCode)
+	}
+}
+
+func TestEmailVerificationNeedsSecret(t *testing.T) {
+	t.Setenv("EMAIL_VERIFICATION_SECRET", "")
+	t.Setenv("EMAIL_VERIFICATION", "")
+	if err := handlers.CheckEmailVerificationConfig(); err != nil {
+		t.Errorf("switch off: expected no error, got %v", err)
+	}
+	for _, mode := range []string{"login", "tasks"} {
+		t.Setenv("EMAIL_VERIFICATION", mode)
+		if err := handlers.CheckEmailVerificationConfig(); err == nil {
+			t.Errorf("%s without a secret: expected an error", mode)
+		}
+	}
+	t.Setenv("EMAIL_VERIFICATION_SECRET", "kisa")
+	if err := handlers.CheckEmailVerificationConfig(); err == nil {
+		t.Error("short secret: expected an error")
+	}
+	t.Setenv("EMAIL_VERIFICATION_SECRET", strings.Repeat("x", auth.MinEmailVerificationSecret))
+	if err := handlers.CheckEmailVerificationConfig(); err != nil {
+		t.Errorf("with a secret: expected no error, got %v", err)
+	}
+}
+
+func TestVerifyEmailRejectsLinksSignedWithJWTSecret(t *testing.T) {
+	env := newAccountTestApp(t)
+	payload := fmt.Sprintf("%d:%d:%s", models.Users[0].ID, time.Now().Add(time.Hour).Unix(), env.email)
+	mac := hmac.New(sha256.New, []byte("gizliAnahtar"))
+	mac.Write([]byte("verify-email:" + payload))
+	forged := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	if resp, out := doJSON(t, env.app, "GET", "/verify-email?token="+url.QueryEscape(forged), "", nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("forged link: expected 400, got %d %v", resp.StatusCode, out)
+	}
+}
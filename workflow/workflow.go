@@ -0,0 +1,164 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sync"
+)
+
+// Workflow describes the statuses a task can have and how it may move between them
+type Workflow struct {
+	// Statuses in board order; the first one is the initial status
+	Statuses []string `json:"statuses"`
+	// Done lists the statuses that count as finished
+	Done []string `json:"done"`
+	// Transitions maps a status to the statuses reachable with a normal update
+	Transitions map[string][]string `json:"transitions"`
+	// Reopen maps a done status to the statuses reachable only through an explicit reopen
+	Reopen map[string][]string `json:"reopen"`
+}
+
+// builtin is the workflow used when TASK_WORKFLOW_FILE is not set
+var builtin = Workflow{
+	Statuses: []string{"pending", "in_progress", "completed"},
+	Done:     []string{"completed"},
+	Transitions: map[string][]string{
+		"pending":     {"in_progress", "completed"},
+		"in_progress": {"pending", "completed"},
+	},
+	Reopen: map[string][]string{
+		"completed": {"pending", "in_progress"},
+	},
+}
+
+var (
+	defaultOnce     sync.Once
+	defaultWorkflow *Workflow
+)
+
+// Default returns the workflow loaded from the JSON file in TASK_WORKFLOW_FILE,
+// or the built-in pending/in_progress/completed workflow.
+func Default() *Workflow {
+	defaultOnce.Do(func() {
+		defaultWorkflow = &builtin
+		path := os.Getenv("TASK_WORKFLOW_FILE")
+		if path == "" {
+			return
+		}
+		w, err := LoadFile(path)
+		if err != nil {
+			log.Printf("Failed to load workflow from %s, using built-in workflow: %v", path, err)
+			return
+		}
+		defaultWorkflow = w
+	})
+	return defaultWorkflow
+}
+
+// LoadFile reads and validates a workflow definition from a JSON file
+func LoadFile(path string) (*Workflow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var w Workflow
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, err
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Validate checks that the workflow only refers to statuses it defines
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("workflow has no statuses")
+	}
+	seen := make(map[string]bool, len(w.Statuses))
+	for _, s := range w.Statuses {
+		if s == "" {
+			return fmt.Errorf("workflow has an empty status")
+		}
+		if seen[s] {
+			return fmt.Errorf("duplicate status %q", s)
+		}
+		seen[s] = true
+	}
+	for _, s := range w.Done {
+		if !seen[s] {
+			return fmt.Errorf("done status %q is not defined", s)
+		}
+	}
+	for _, graph := range []map[string][]string{w.Transitions, w.Reopen} {
+		for from, targets := range graph {
+			if !seen[from] {
+				return fmt.Errorf("transition from unknown status %q", from)
+			}
+			for _, to := range targets {
+				if !seen[to] {
+					return fmt.Errorf("transition from %q to unknown status %q", from, to)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Initial returns the status new tasks start in
+func (w *Workflow) Initial() string {
+	return w.Statuses[0]
+}
+
+// HasStatus reports whether the status is part of the workflow
+func (w *Workflow) HasStatus(status string) bool {
+	return slices.Contains(w.Statuses, status)
+}
+
+// IsDone reports whether the status counts as finished
+func (w *Workflow) IsDone(status string) bool {
+	return slices.Contains(w.Done, status)
+}
+
+// CanTransition reports whether a normal update may move a task from one status to another.
+// Staying in the same status is always allowed.
+func (w *Workflow) CanTransition(from, to string) bool {
+	return from == to || slices.Contains(w.Transitions[from], to)
+}
+
+// CanReopen reports whether an explicit reopen may move a task from one status to another
+func (w *Workflow) CanReopen(from, to string) bool {
+	return slices.Contains(w.Reopen[from], to)
+}
+
+// Removed returns the statuses of w that next no longer has, in board order
+func (w *Workflow) Removed(next *Workflow) []string {
+	var removed []string
+	for _, s := range w.Statuses {
+		if !next.HasStatus(s) {
+			removed = append(removed, s)
+		}
+	}
+	return removed
+}
+
+// Clone returns a deep copy, so a project can change its workflow without touching the source
+func (w *Workflow) Clone() Workflow {
+	c := Workflow{
+		Statuses:    slices.Clone(w.Statuses),
+		Done:        slices.Clone(w.Done),
+		Transitions: make(map[string][]string, len(w.Transitions)),
+		Reopen:      make(map[string][]string, len(w.Reopen)),
+	}
+	for from, to := range w.Transitions {
+		c.Transitions[from] = slices.Clone(to)
+	}
+	for from, to := range w.Reopen {
+		c.Reopen[from] = slices.Clone(to)
+	}
+	return c
+}